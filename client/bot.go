@@ -0,0 +1,153 @@
+// Bot: subscribes to rooms and answers commands like "!deploy staging"
+// Slash is not used as prefix, because chat page runs "/..." messages as its own commands
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Prefix of bot commands, if Bot.Prefix is not set
+const DefaultPrefix = "!"
+
+// Handles command, non-empty reply is posted to the room of the command
+type CommandFunc func(ctx context.Context, m Message, args string) (reply string, err error)
+
+type botCommand struct {
+	help string
+	fn   CommandFunc
+}
+
+// Bot of the chat, commands are registered before Run
+type Bot struct {
+	Client *Client
+	Rooms  []string
+	// Prefix of commands, DefaultPrefix if empty
+	Prefix string
+	// Called for every message of other users, that is not a command
+	OnMessage func(ctx context.Context, m Message)
+	// Called on errors of polling and of commands, bot keeps running
+	OnError func(err error)
+
+	commands map[string]botCommand
+	login    string
+}
+
+// Creates bot for rooms, client must be logged in or use api token
+func NewBot(c *Client, rooms ...string) *Bot {
+	return &Bot{Client: c, Rooms: rooms, commands: make(map[string]botCommand)}
+}
+
+// Registers command, "help" is built in and lists commands
+func (b *Bot) Command(name, help string, fn CommandFunc) {
+	if b.commands == nil {
+		b.commands = make(map[string]botCommand)
+	}
+	b.commands[strings.ToLower(name)] = botCommand{help: help, fn: fn}
+}
+
+// Runs bot until ctx is canceled or some room fails permanently
+func (b *Bot) Run(ctx context.Context) error {
+	me, err := b.Client.Me(ctx)
+	if err != nil {
+		return err
+	}
+	b.login = me.Login
+	if _, ok := b.commands["help"]; !ok {
+		b.Command("help", "Lists commands", b.help)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	errc := make(chan error, len(b.Rooms))
+	for _, room := range b.Rooms {
+		wg.Add(1)
+		go func(room string) {
+			defer wg.Done()
+			err := b.Client.Subscribe(ctx, room, SubscribeOptions{OnError: b.OnError}, func(m Message) { b.handle(ctx, m) })
+			if err != nil && ctx.Err() == nil {
+				errc <- fmt.Errorf("room %s: %w", room, err)
+				cancel()
+			}
+		}(room)
+	}
+	wg.Wait()
+	select {
+	case err := <-errc:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
+
+// Dispatches message of the room to command or OnMessage, own messages are skipped
+func (b *Bot) handle(ctx context.Context, m Message) {
+	if m.Author == b.login {
+		return
+	}
+	name, args, ok := b.parse(m.Text)
+	if !ok {
+		if b.OnMessage != nil {
+			b.OnMessage(ctx, m)
+		}
+		return
+	}
+	cmd, ok := b.commands[name]
+	if !ok {
+		return
+	}
+	reply, err := cmd.fn(ctx, m, args)
+	if err != nil {
+		b.fail(fmt.Errorf("command %s%s: %w", b.prefix(), name, err))
+		reply = "Error: " + err.Error()
+	}
+	if reply == "" {
+		return
+	}
+	if _, err := b.Client.Send(ctx, m.Room, reply); err != nil {
+		b.fail(err)
+	}
+}
+
+// Splits "!name args" into name of the command and args
+func (b *Bot) parse(text string) (name, args string, ok bool) {
+	if !strings.HasPrefix(text, b.prefix()) {
+		return "", "", false
+	}
+	name = strings.TrimPrefix(text, b.prefix())
+	if i := strings.IndexAny(name, " \t"); i >= 0 {
+		name, args = name[:i], strings.TrimSpace(name[i:])
+	}
+	return strings.ToLower(name), args, name != ""
+}
+
+func (b *Bot) prefix() string {
+	if b.Prefix == "" {
+		return DefaultPrefix
+	}
+	return b.Prefix
+}
+
+func (b *Bot) fail(err error) {
+	if b.OnError != nil {
+		b.OnError(err)
+	}
+}
+
+func (b *Bot) help(ctx context.Context, m Message, args string) (string, error) {
+	names := make([]string, 0, len(b.commands))
+	for name := range b.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, b.prefix()+name+" - "+b.commands[name].help)
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,363 @@
+// Package client is Go SDK of the chat: it talks to api v1 of main (see /api/v1/openapi.json)
+// Client authenticates with login and password (session bearer token) or with personal api token,
+// expired sessions are renewed by logging in again
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix of all api routes
+const apiPrefix = "/api/v1"
+
+// Client of the chat, safe for concurrent use
+type Client struct {
+	baseURL string
+	http    *http.Client
+
+	mu    sync.Mutex
+	token string
+	// Credentials of Login, kept to renew expired session
+	login    string
+	password string
+}
+
+// Creates client of chat server, e.g. New("http://localhost:8080")
+func New(baseURL string) *Client {
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), http: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Creates client, that authenticates with personal api token ("crt_...")
+func NewWithToken(baseURL, token string) *Client {
+	c := New(baseURL)
+	c.token = token
+	return c
+}
+
+// Replaces http client, e.g. to set TLS config or timeout
+func (c *Client) SetHTTPClient(h *http.Client) {
+	c.http = h
+}
+
+// Logs in with login and password, later requests use session of the login
+func (c *Client) Login(ctx context.Context, login, password string) error {
+	var token tokenResponse
+	err := c.do(ctx, http.MethodPost, "/auth/login", nil, map[string]string{"login": login, "password": password}, &token, nil, false)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.token, c.login, c.password = token.Token, login, password
+	c.mu.Unlock()
+	return nil
+}
+
+// Ends session of Login, client can not be used until next Login
+func (c *Client) Logout(ctx context.Context) error {
+	err := c.do(ctx, http.MethodPost, "/auth/logout", nil, nil, nil, nil, false)
+	c.mu.Lock()
+	c.token, c.login, c.password = "", "", ""
+	c.mu.Unlock()
+	return err
+}
+
+// Returns current user
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var u User
+	if err := c.do(ctx, http.MethodGet, "/users/me", nil, nil, &u, nil, true); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// Returns all rooms sorted by name
+func (c *Client) Rooms(ctx context.Context) ([]Room, error) {
+	var rooms []Room
+	if err := c.do(ctx, http.MethodGet, "/rooms", nil, nil, &rooms, nil, true); err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+// Posts message to the room
+func (c *Client) Send(ctx context.Context, room, text string) (*Message, error) {
+	var m Message
+	if err := c.do(ctx, http.MethodPost, roomPath(room)+"/messages", nil, map[string]string{"text": text}, &m, nil, true); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Posts reply to message parent, it goes to the thread of the message
+func (c *Client) Reply(ctx context.Context, room, parent, text string) (*Message, error) {
+	var m Message
+	if err := c.do(ctx, http.MethodPost, roomPath(room)+"/messages", nil, map[string]string{"text": text, "parent": parent}, &m, nil, true); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Changes text of own message
+func (c *Client) Edit(ctx context.Context, room, id, text string) (*Message, error) {
+	var m Message
+	path := roomPath(room) + "/messages/" + url.PathEscape(id)
+	if err := c.do(ctx, http.MethodPatch, path, nil, map[string]string{"text": text}, &m, nil, true); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Deletes own message
+func (c *Client) Delete(ctx context.Context, room, id string) error {
+	return c.do(ctx, http.MethodDelete, roomPath(room)+"/messages/"+url.PathEscape(id), nil, nil, nil, nil, true)
+}
+
+// Reports message to moderators, once per message
+func (c *Client) Report(ctx context.Context, room, id, reason string) error {
+	path := roomPath(room) + "/messages/" + url.PathEscape(id) + "/reports"
+	return c.do(ctx, http.MethodPost, path, nil, map[string]string{"reason": reason}, nil, nil, true)
+}
+
+// Adds reaction (emoji) of current user to message or takes it back, returns the message with its reactions
+func (c *Client) React(ctx context.Context, room, id, emoji string) (*Message, error) {
+	var m Message
+	path := roomPath(room) + "/messages/" + url.PathEscape(id) + "/reactions"
+	if err := c.do(ctx, http.MethodPost, path, nil, map[string]string{"emoji": emoji}, &m, nil, true); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Returns page of messages of the room older than message before (newest page if before is empty),
+// messages are in chronological order, next page is requested with before=page.Next
+func (c *Client) Messages(ctx context.Context, room, before string, limit int) ([]Message, *Page, error) {
+	query := url.Values{}
+	if before != "" {
+		query.Set("before", before)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var messages []Message
+	page := &Page{}
+	if err := c.do(ctx, http.MethodGet, roomPath(room)+"/messages", query, nil, &messages, page, true); err != nil {
+		return nil, nil, err
+	}
+	return messages, page, nil
+}
+
+// Returns thread of the message with page of replies older than reply before (newest page if before is empty),
+// next page is requested with before=page.Next
+func (c *Client) Thread(ctx context.Context, room, id, before string, limit int) (*Thread, *Page, error) {
+	query := url.Values{}
+	if before != "" {
+		query.Set("before", before)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var thread Thread
+	page := &Page{}
+	path := roomPath(room) + "/messages/" + url.PathEscape(id) + "/thread"
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &thread, page, true); err != nil {
+		return nil, nil, err
+	}
+	return &thread, page, nil
+}
+
+// Returns mentions of current user, newest first, only unread ones if unreadOnly
+func (c *Client) Mentions(ctx context.Context, unreadOnly bool, limit int) ([]Mention, error) {
+	query := url.Values{}
+	if unreadOnly {
+		query.Set("unread", "true")
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var mentions []Mention
+	if err := c.do(ctx, http.MethodGet, "/mentions", query, nil, &mentions, nil, true); err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+// Returns number of unread mentions of current user
+func (c *Client) UnreadMentions(ctx context.Context) (int64, error) {
+	var count mentionsCount
+	if err := c.do(ctx, http.MethodGet, "/mentions/count", nil, nil, &count, nil, true); err != nil {
+		return 0, err
+	}
+	return count.Unread, nil
+}
+
+// Marks mentions read, all of them if no ids are given, returns number of unread ones left
+func (c *Client) MarkMentionsRead(ctx context.Context, ids ...string) (int64, error) {
+	var count mentionsCount
+	if ids == nil {
+		ids = []string{}
+	}
+	if err := c.do(ctx, http.MethodPost, "/mentions/read", nil, map[string][]string{"ids": ids}, &count, nil, true); err != nil {
+		return 0, err
+	}
+	return count.Unread, nil
+}
+
+// Marks messages of the room read up to the message, returns unread messages left in the room
+func (c *Client) MarkRead(ctx context.Context, room, id string) (*Unread, error) {
+	var u Unread
+	if err := c.do(ctx, http.MethodPut, roomPath(room)+"/read", nil, map[string]string{"message_id": id}, &u, nil, true); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// Returns unread messages of current user in every room
+func (c *Client) Unread(ctx context.Context) ([]Unread, error) {
+	var unread []Unread
+	if err := c.do(ctx, http.MethodGet, "/unread", nil, nil, &unread, nil, true); err != nil {
+		return nil, err
+	}
+	return unread, nil
+}
+
+// Returns logins of users, who have read the room up to the message or further
+func (c *Client) Readers(ctx context.Context, room, id string) ([]string, error) {
+	var readers []struct {
+		Login string `json:"login"`
+	}
+	path := roomPath(room) + "/messages/" + url.PathEscape(id) + "/readers"
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &readers, nil, true); err != nil {
+		return nil, err
+	}
+	logins := make([]string, len(readers))
+	for n, r := range readers {
+		logins[n] = r.Login
+	}
+	return logins, nil
+}
+
+// Walks history of the room from the newest message back, until fn returns false or history ends
+func (c *Client) History(ctx context.Context, room string, fn func(m Message) bool) error {
+	before := ""
+	for {
+		messages, page, err := c.Messages(ctx, room, before, 0)
+		if err != nil {
+			return err
+		}
+		for n := len(messages) - 1; n >= 0; n-- {
+			if !fn(messages[n]) {
+				return nil
+			}
+		}
+		if !page.HasMore || page.Next == "" {
+			return nil
+		}
+		before = page.Next
+	}
+}
+
+func roomPath(room string) string {
+	return "/rooms/" + url.PathEscape(room)
+}
+
+// Envelope of api responses
+type envelope struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination *Page           `json:"pagination"`
+	Error      *Error          `json:"error"`
+	RequestID  string          `json:"request_id"`
+}
+
+type tokenResponse struct {
+	Token string `json:"token"`
+}
+
+// Sends api request, decodes data into out and pagination into page.
+// Expired session is renewed once, when client knows credentials and renew is set.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}, page *Page, renew bool) error {
+	err := c.send(ctx, method, path, query, body, out, page)
+	if e, ok := err.(*Error); ok && e.Status == http.StatusUnauthorized && renew {
+		c.mu.Lock()
+		login, password := c.login, c.password
+		c.mu.Unlock()
+		if password == "" {
+			return err
+		}
+		if err := c.Login(ctx, login, password); err != nil {
+			return err
+		}
+		return c.send(ctx, method, path, query, body, out, page)
+	}
+	return err
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body, out interface{}, page *Page) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+	u := c.baseURL + apiPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.mu.Lock()
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	c.mu.Unlock()
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("chat answered %d with malformed body: %w", resp.StatusCode, err)
+	}
+	if env.Error != nil || resp.StatusCode >= 300 {
+		e := env.Error
+		if e == nil {
+			e = &Error{Code: http.StatusText(resp.StatusCode), Message: http.StatusText(resp.StatusCode)}
+		}
+		e.Status = resp.StatusCode
+		e.RequestID = env.RequestID
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			e.RetryAfter = time.Duration(s) * time.Second
+		}
+		return e
+	}
+	if page != nil && env.Pagination != nil {
+		*page = *env.Pagination
+	}
+	if out != nil && len(env.Data) > 0 {
+		return json.Unmarshal(env.Data, out)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Terminal client of the chat: logs in like the browser page and shows messages of a room with an input line
+// Usage: chatcli -server http://localhost:8080 -user alice [-room general], password is asked or taken from CHAT_PASSWORD
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// The page polls every two seconds as well
+const pollInterval = 2 * time.Second
+
+type pollResult struct {
+	room     string
+	messages []siteMessage
+	readOnly bool
+	err      error
+}
+
+type postResult struct {
+	text    string
+	reply   string
+	command bool
+	err     error
+}
+
+func main() {
+	server := flag.String("server", "http://localhost:8080", "Url of chat server")
+	user := flag.String("user", "", "Login")
+	room := flag.String("room", "general", "Room to open")
+	flag.Parse()
+	if *user == "" {
+		fmt.Fprintln(os.Stderr, "chatcli: -user is required")
+		os.Exit(2)
+	}
+	password := os.Getenv("CHAT_PASSWORD")
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		p, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "chatcli:", err)
+			os.Exit(1)
+		}
+		password = string(p)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newSite(*server, *user, password)
+	if err := s.signIn(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "chatcli: login failed:", err)
+		os.Exit(1)
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "chatcli:", err)
+		os.Exit(1)
+	}
+	// Alternate screen keeps terminal history clean
+	fmt.Print("\x1b[?1049h")
+	err = run(ctx, s, *room)
+	fmt.Print("\x1b[?1049l")
+	term.Restore(int(os.Stdin.Fd()), state)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "chatcli:", err)
+		os.Exit(1)
+	}
+}
+
+// Main loop: keys and results of background polls and posts come through channels
+func run(ctx context.Context, s *site, room string) error {
+	scr := &screen{out: os.Stdout, room: room}
+	if rooms, err := s.rooms(ctx); err == nil {
+		scr.rooms = rooms
+	} else {
+		scr.status = "Rooms are not known: " + err.Error()
+	}
+	if len(scr.rooms) == 0 {
+		scr.rooms = []string{room}
+	}
+
+	keys := make(chan key)
+	go readKeys(os.Stdin, keys)
+	polled := make(chan pollResult, 1)
+	posted := make(chan postResult, 1)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	seen := make(map[string]bool)
+	polling := false
+	poll := func() {
+		if polling {
+			return
+		}
+		polling = true
+		go func(room string) {
+			messages, readOnly, err := s.messages(ctx, room)
+			polled <- pollResult{room: room, messages: messages, readOnly: readOnly, err: err}
+		}(scr.room)
+	}
+	join := func(room string) {
+		scr.room, scr.lines, scr.scroll = room, nil, 0
+		seen = make(map[string]bool)
+		poll()
+	}
+	poll()
+	scr.draw()
+
+	for {
+		select {
+		case k := <-keys:
+			switch k.kind {
+			case keyQuit:
+				return nil
+			case keyRune:
+				scr.input = append(scr.input, k.r)
+			case keyBackspace:
+				if len(scr.input) > 0 {
+					scr.input = scr.input[:len(scr.input)-1]
+				}
+			case keyTab:
+				join(nextRoom(scr.rooms, scr.room))
+			case keyUp:
+				scr.scrollBy(1)
+			case keyDown:
+				scr.scrollBy(-1)
+			case keyPageUp:
+				_, h := termSize()
+				scr.scrollBy(h - 4)
+			case keyPageDown:
+				_, h := termSize()
+				scr.scrollBy(-(h - 4))
+			case keyEnter:
+				text := strings.TrimSpace(string(scr.input))
+				scr.input = nil
+				if text == "" {
+					break
+				}
+				// Commands of the client, the rest goes to the server as the page sends it
+				parts := strings.SplitN(text, " ", 2)
+				args := ""
+				if len(parts) > 1 {
+					args = strings.TrimSpace(parts[1])
+				}
+				switch parts[0] {
+				case "/quit":
+					return nil
+				case "/join":
+					if args != "" {
+						join(args)
+					}
+				case "/rooms":
+					if rooms, err := s.rooms(ctx); err == nil {
+						scr.rooms = rooms
+						scr.add("Rooms: " + strings.Join(rooms, ", "))
+					} else {
+						scr.status = err.Error()
+					}
+				default:
+					go func(room string) {
+						reply, command, err := s.post(ctx, room, text)
+						posted <- postResult{text: text, reply: reply, command: command, err: err}
+					}(scr.room)
+				}
+			}
+		case res := <-polled:
+			polling = false
+			if res.room != scr.room {
+				poll()
+				break
+			}
+			if res.err != nil {
+				scr.status = res.err.Error()
+				break
+			}
+			scr.status = ""
+			if res.readOnly {
+				scr.status = "Chat is temporarily read-only"
+			}
+			for _, m := range res.messages {
+				if !seen[m.ID] {
+					seen[m.ID] = true
+					scr.add(format(m))
+				}
+			}
+		case res := <-posted:
+			if res.err != nil {
+				scr.status = "Not sent: " + res.err.Error()
+			} else if res.command && res.reply != "" {
+				scr.add(res.reply)
+			}
+			poll()
+		case <-ticker.C:
+			poll()
+		}
+		scr.draw()
+	}
+}
+
+func nextRoom(rooms []string, current string) string {
+	for n, r := range rooms {
+		if r == current {
+			return rooms[(n+1)%len(rooms)]
+		}
+	}
+	return rooms[0]
+}
+
+// Message as one line: time, author and text
+func format(m siteMessage) string {
+	t := m.Time
+	if len(t) > 8 {
+		t = t[len(t)-8:]
+	}
+	name := m.Name
+	if m.Nick != "" {
+		name = m.Nick
+	}
+	if m.Bot {
+		name += " (bot)"
+	}
+	return t + " " + name + ": " + m.Message
+}
@@ -0,0 +1,207 @@
+// Terminal screen: header with rooms, scrollable messages, status line and input line
+// Screen is redrawn as a whole on every change, terminal is in raw mode while chatcli runs
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Keys, that are not text
+const (
+	keyNone = iota
+	keyRune
+	keyEnter
+	keyBackspace
+	keyTab
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyQuit
+)
+
+type key struct {
+	kind int
+	r    rune
+}
+
+type screen struct {
+	out    io.Writer
+	room   string
+	rooms  []string
+	lines  []string
+	input  []rune
+	status string
+	// Lines scrolled up from the bottom
+	scroll int
+}
+
+// Size of terminal, 80x24 when it is not known
+func termSize() (int, int) {
+	w, h, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+// Adds line to messages, view stays where it is when scrolled up
+func (s *screen) add(line string) {
+	if s.scroll > 0 {
+		w, _ := termSize()
+		s.scroll += len(wrap(line, w))
+	}
+	s.lines = append(s.lines, line)
+}
+
+// Scrolls by n lines, positive n goes up
+func (s *screen) scrollBy(n int) {
+	w, h := termSize()
+	total := 0
+	for _, l := range s.lines {
+		total += len(wrap(l, w))
+	}
+	s.scroll += n
+	if max := total - (h - 3); s.scroll > max {
+		s.scroll = max
+	}
+	if s.scroll < 0 {
+		s.scroll = 0
+	}
+}
+
+func (s *screen) draw() {
+	w, h := termSize()
+	view := h - 3
+	var rows []string
+	for _, l := range s.lines {
+		rows = append(rows, wrap(l, w)...)
+	}
+	end := len(rows) - s.scroll
+	if end < 0 {
+		end = 0
+	}
+	start := end - view
+	if start < 0 {
+		start = 0
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("\x1b[H\x1b[2J")
+	header := ""
+	for _, r := range s.rooms {
+		if r == s.room {
+			header += "[" + r + "] "
+		} else {
+			header += r + " "
+		}
+	}
+	b.WriteString("\x1b[7m" + pad(header+"| Tab room, PgUp/PgDn scroll, /join /rooms /quit", w) + "\x1b[0m\r\n")
+	for n := start; n < start+view; n++ {
+		if n < end {
+			b.WriteString(rows[n])
+		}
+		b.WriteString("\r\n")
+	}
+	status := s.status
+	if s.scroll > 0 {
+		status = fmt.Sprintf("-- %d more below -- ", s.scroll) + status
+	}
+	b.WriteString("\x1b[2m" + pad(status, w) + "\x1b[0m\r\n")
+
+	// Input line shows its end, when it is longer than screen
+	prompt := s.room + "> "
+	input := s.input
+	if max := w - utf8.RuneCountInString(prompt) - 1; max > 0 && len(input) > max {
+		input = input[len(input)-max:]
+	}
+	b.WriteString(prompt + string(input))
+	io.WriteString(s.out, b.String())
+}
+
+// Cuts line to rows of the screen width
+func wrap(line string, width int) []string {
+	var rows []string
+	for _, l := range strings.Split(line, "\n") {
+		runes := []rune(l)
+		for len(runes) > width {
+			rows = append(rows, string(runes[:width]))
+			runes = runes[width:]
+		}
+		rows = append(rows, string(runes))
+	}
+	return rows
+}
+
+func pad(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// Reads keys of raw terminal until it is closed
+func readKeys(in io.Reader, keys chan<- key) {
+	r := bufio.NewReader(in)
+	for {
+		c, _, err := r.ReadRune()
+		if err != nil {
+			keys <- key{kind: keyQuit}
+			return
+		}
+		switch c {
+		case '\r', '\n':
+			keys <- key{kind: keyEnter}
+		case 127, '\b':
+			keys <- key{kind: keyBackspace}
+		case '\t':
+			keys <- key{kind: keyTab}
+		case 3, 4:
+			keys <- key{kind: keyQuit}
+		case 0x1b:
+			keys <- escape(r)
+		default:
+			if c >= ' ' {
+				keys <- key{kind: keyRune, r: c}
+			}
+		}
+	}
+}
+
+// Reads escape sequence of arrows and page keys, other sequences are skipped
+func escape(r *bufio.Reader) key {
+	if c, err := r.ReadByte(); err != nil || c != '[' {
+		return key{kind: keyNone}
+	}
+	seq := ""
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return key{kind: keyNone}
+		}
+		seq += string(c)
+		if (c >= 'A' && c <= 'Z') || c == '~' {
+			break
+		}
+	}
+	switch seq {
+	case "A":
+		return key{kind: keyUp}
+	case "B":
+		return key{kind: keyDown}
+	case "5~":
+		return key{kind: keyPageUp}
+	case "6~":
+		return key{kind: keyPageDown}
+	}
+	return key{kind: keyNone}
+}
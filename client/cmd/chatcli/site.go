@@ -0,0 +1,194 @@
+// Chat server as the browser page sees it: session cookie from /login, messages from /messages, posts to /main
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Header of answers of expired sessions, page goes to its value
+const redirectHeader = "redirect"
+
+// Headers of answers of /main
+const (
+	commandHeader  = "X-Chat-Command"
+	readOnlyHeader = "X-Chat-Read-Only"
+)
+
+var errSessionExpired = errors.New("session expired")
+
+// Message as /messages returns it
+type siteMessage struct {
+	ID      string `json:"id"`
+	Time    string `json:"time"`
+	Name    string `json:"name"`
+	Nick    string `json:"nick"`
+	Message string `json:"message"`
+	Bot     bool   `json:"bot"`
+}
+
+// Session of one user, logs in again when session expires
+type site struct {
+	base     string
+	http     *http.Client
+	login    string
+	password string
+}
+
+func newSite(base, login, password string) *site {
+	jar, _ := cookiejar.New(nil)
+	return &site{
+		base:     strings.TrimSuffix(base, "/"),
+		http:     &http.Client{Jar: jar, Timeout: 10 * time.Second},
+		login:    login,
+		password: password,
+	}
+}
+
+// Logs in with the login form, session cookie goes to the jar
+func (s *site) signIn(ctx context.Context) error {
+	form := url.Values{"username": {s.login}, "password": {s.password}}
+	resp, err := s.send(ctx, http.MethodPost, "/login", form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusForbidden {
+		return errors.New("username and/or password do not match")
+	}
+	if err := statusError(resp); err != nil {
+		return err
+	}
+	u, _ := url.Parse(s.base)
+	for _, c := range s.http.Jar.Cookies(u) {
+		if c.Name == "session" && c.Value != "" {
+			return nil
+		}
+	}
+	return errors.New("server did not set session cookie")
+}
+
+// Returns last messages of the room, tells if chat is read-only now
+func (s *site) messages(ctx context.Context, room string) ([]siteMessage, bool, error) {
+	var messages []siteMessage
+	readOnly := false
+	err := s.retry(ctx, func() error {
+		resp, err := s.send(ctx, http.MethodGet, "/messages?room="+url.QueryEscape(room), nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if err := statusError(resp); err != nil {
+			return err
+		}
+		readOnly = resp.Header.Get(readOnlyHeader) != ""
+		return json.NewDecoder(resp.Body).Decode(&messages)
+	})
+	return messages, readOnly, err
+}
+
+// Posts message to the room, for slash commands returns their private reply
+func (s *site) post(ctx context.Context, room, text string) (reply string, command bool, err error) {
+	err = s.retry(ctx, func() error {
+		resp, err := s.send(ctx, http.MethodPost, "/main", url.Values{"usermsg": {text}, "room": {room}})
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if err := statusError(resp); err != nil {
+			return err
+		}
+		if resp.Header.Get(commandHeader) == "" {
+			return nil
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		reply, command = string(body), true
+		return err
+	})
+	return reply, command, err
+}
+
+// Returns names of all rooms, api accepts session cookie as well
+func (s *site) rooms(ctx context.Context) ([]string, error) {
+	var names []string
+	err := s.retry(ctx, func() error {
+		resp, err := s.send(ctx, http.MethodGet, "/api/v1/rooms", nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized {
+			return errSessionExpired
+		}
+		var env struct {
+			Data []struct {
+				Name string `json:"name"`
+			} `json:"data"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+			return err
+		}
+		if env.Error != nil {
+			return errors.New(env.Error.Message)
+		}
+		names = names[:0]
+		for _, r := range env.Data {
+			names = append(names, r.Name)
+		}
+		return nil
+	})
+	return names, err
+}
+
+// Runs request, logs in again once if session expired
+func (s *site) retry(ctx context.Context, fn func() error) error {
+	err := fn()
+	if err != errSessionExpired {
+		return err
+	}
+	if err := s.signIn(ctx); err != nil {
+		return err
+	}
+	return fn()
+}
+
+func (s *site) send(ctx context.Context, method, path string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.base+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return s.http.Do(req)
+}
+
+// Error of answer: expired session, read-only or unavailable chat, restricted user or rejected message, other failures
+func statusError(resp *http.Response) error {
+	if resp.Header.Get(redirectHeader) != "" {
+		return errSessionExpired
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	// Read-only chat, restricted user or rejected message: body tells why
+	switch {
+	case resp.Header.Get(readOnlyHeader) != "", resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusForbidden, resp.StatusCode == http.StatusBadRequest, resp.StatusCode == http.StatusTooManyRequests:
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.New(strings.TrimSpace(string(body)))
+	}
+	return fmt.Errorf("server answered %s", resp.Status)
+}
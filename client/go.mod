@@ -0,0 +1,5 @@
+module client
+
+go 1.16
+
+require golang.org/x/term v0.0.0-20220411215600-e5f449aeb171
@@ -0,0 +1,111 @@
+// Subscription to new messages: api has no push, so the newest page of the room is polled,
+// gaps after long pauses are filled by walking pages back, failures are retried with backoff
+
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Defaults of subscription
+const (
+	DefaultPollInterval = 2 * time.Second
+	maxBackoff          = 30 * time.Second
+	// Pages walked back to fill a gap, older messages of the gap are skipped
+	maxCatchUpPages = 10
+)
+
+// Options of Subscribe, zero value is usable
+type SubscribeOptions struct {
+	// Time between polls, DefaultPollInterval if zero
+	PollInterval time.Duration
+	// Id of the last seen message, delivery starts after it. Empty means start after the newest message.
+	After string
+	// Called on every failed poll, subscription retries after backoff anyway
+	OnError func(err error)
+}
+
+// Calls fn for every new message of the room in chronological order, until ctx is canceled.
+// Returns ctx error or error, that will not go away by retrying (room not found, no permission).
+func (c *Client) Subscribe(ctx context.Context, room string, opts SubscribeOptions, fn func(m Message)) error {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	last := opts.After
+	started := last != ""
+	backoff := interval
+
+	for {
+		messages, err := c.newMessages(ctx, room, last, started)
+		wait := interval
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if e, ok := err.(*Error); ok && !e.Temporary() {
+				return err
+			}
+			if opts.OnError != nil {
+				opts.OnError(err)
+			}
+			wait = backoff
+			if e, ok := err.(*Error); ok && e.RetryAfter > wait {
+				wait = e.RetryAfter
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		} else {
+			backoff = interval
+			for _, m := range messages {
+				if started {
+					fn(m)
+				}
+				last = m.ID
+			}
+			started = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Returns messages newer than last in chronological order, only the newest one until subscription is started
+func (c *Client) newMessages(ctx context.Context, room, last string, started bool) ([]Message, error) {
+	var newer []Message
+	before := ""
+	for pages := 0; pages < maxCatchUpPages; pages++ {
+		messages, page, err := c.Messages(ctx, room, before, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !started {
+			if len(messages) == 0 {
+				return nil, nil
+			}
+			return messages[len(messages)-1:], nil
+		}
+		// Ids grow with time, so page is cut at the last seen one
+		reached := false
+		for n := len(messages) - 1; n >= 0; n-- {
+			if messages[n].ID <= last {
+				messages = messages[n+1:]
+				reached = true
+				break
+			}
+		}
+		newer = append(messages, newer...)
+		if reached || !page.HasMore || page.Next == "" {
+			break
+		}
+		before = page.Next
+	}
+	return newer, nil
+}
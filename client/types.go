@@ -0,0 +1,126 @@
+// Types of api v1 as client sees them
+
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Message of a room
+type Message struct {
+	ID     string `json:"id"`
+	Room   string `json:"room"`
+	Author string `json:"author"`
+	// Display name of the author, empty if author has none
+	Nick string `json:"nick,omitempty"`
+	Text string `json:"text"`
+	Time string `json:"time"`
+	// Message is posted by integration
+	Bot bool `json:"bot,omitempty"`
+	// Time of last edit
+	Edited string `json:"edited,omitempty"`
+	// Reactions sorted by emoji
+	Reactions []Reaction `json:"reactions,omitempty"`
+	// Id of the thread, if the message is a reply
+	Parent string `json:"parent,omitempty"`
+	// Replies of the thread and time of the last one, for messages of the room stream
+	Replies   int    `json:"replies,omitempty"`
+	LastReply string `json:"last_reply,omitempty"`
+}
+
+// Thread: its first message and a page of replies in chronological order
+type Thread struct {
+	Parent  Message   `json:"parent"`
+	Replies []Message `json:"replies"`
+}
+
+// Reaction to message: emoji and users, who reacted with it
+type Reaction struct {
+	Emoji  string   `json:"emoji"`
+	Count  int      `json:"count"`
+	Logins []string `json:"logins"`
+	// Current user reacted with this emoji
+	Me bool `json:"me,omitempty"`
+}
+
+// Mention of current user: "@login" in a message of another user
+type Mention struct {
+	ID        string `json:"id"`
+	MessageID string `json:"message_id"`
+	Room      string `json:"room"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	Time      string `json:"time"`
+	// Id of the thread, if the message is a reply
+	Parent string `json:"parent,omitempty"`
+	Read   bool   `json:"read"`
+}
+
+type mentionsCount struct {
+	Unread int64 `json:"unread"`
+}
+
+// Unread messages of the room after the last read one, counted up to 100
+type Unread struct {
+	Room string `json:"room"`
+	// Id of the last read message, empty if the room was never read
+	LastRead string `json:"last_read,omitempty"`
+	Unread   int    `json:"unread"`
+}
+
+// Room of the chat
+type Room struct {
+	Name      string `json:"name"`
+	Topic     string `json:"topic,omitempty"`
+	CreatedBy string `json:"created_by,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// User of the chat
+type User struct {
+	Login     string `json:"login"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+	Nick      string `json:"nick,omitempty"`
+}
+
+// Page of a list, next page is requested with before=Next
+type Page struct {
+	Limit   int    `json:"limit"`
+	HasMore bool   `json:"has_more"`
+	Next    string `json:"next,omitempty"`
+}
+
+// Error of api request
+type Error struct {
+	// Grpc code name, e.g. NotFound
+	Code string `json:"code"`
+	// Machine readable reason, e.g. ROOM_NOT_FOUND
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message"`
+	// Wrong field of request
+	Field string `json:"field,omitempty"`
+	// Http status of response
+	Status    int    `json:"-"`
+	RequestID string `json:"-"`
+	// Time to wait before retry, when server tells it
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *Error) Error() string {
+	msg := "chat api: " + e.Message
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	if e.RequestID != "" {
+		msg += ", request " + e.RequestID
+	}
+	return msg
+}
+
+// Tells if request may succeed later: server is unavailable or limits the caller
+func (e *Error) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= http.StatusInternalServerError
+}
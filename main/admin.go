@@ -0,0 +1,342 @@
+// Admin pages: users and their restrictions, rooms, moderation, reports queue, live statistics, recent logs and audit log
+// Only users with admin role get there, api tokens never do
+
+package main
+
+import (
+	clickhouserpc "chat_room_go/microservices/clickhouse/pb"
+	mongoservice "chat_room_go/microservices/mongodb"
+	mongorpc "chat_room_go/microservices/mongodb/pb"
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// Prefix of admin pages
+const adminPath = "/admin"
+
+// Role, that may manage the chat
+const adminRole = "admin"
+
+// Roles, that admin can give
+var userRoles = []string{"user", adminRole}
+
+// Sections of admin pages, the first one is the default
+var adminTabs = []string{"users", "rooms", "moderation", "reports", "stats", "logs", "audit"}
+
+// Number of logs on logs section
+const adminLogsNumber = 200
+
+// Number of events on audit section
+const adminAuditNumber = 200
+
+// Number of resolved reports on reports section
+const adminResolvedNumber = 50
+
+type adminPage struct {
+	Tab    string
+	Tabs   []string
+	Login  string
+	Roles  []string
+	Error  string
+	Notice string
+	// Search of users or logs
+	Query string
+	Users []*redisrpc.UserInfo
+	// Current restrictions by login
+	Restrictions map[string]*redisrpc.Restriction
+	Kinds        []string
+	Rooms        []*mongorpc.RoomInfo
+	Room         string
+	Messages     []*mongorpc.MessageInfo
+	// Open reports, the queue, and last resolved ones
+	Reports   []*mongorpc.ReportInfo
+	Resolved  []*mongorpc.ReportInfo
+	Decisions []string
+	Logs      []*clickhouserpc.LogEntry
+	// Filter and events of audit section
+	Audit  *clickhouserpc.ListAuditRequest
+	Events []*clickhouserpc.AuditEvent
+	Stats  *adminStats
+	// Token of post forms
+	CSRF string
+}
+
+// Live statistics, page polls them as json
+type adminStats struct {
+	// Users seen within whoWindow, by this instance of main
+	Online   int                 `json:"online"`
+	Rooms    map[string][]string `json:"rooms"`
+	Sessions int                 `json:"sessions"`
+	// Messages posted through this instance, the current hour is the last
+	MessagesPerHour []int  `json:"messages_per_hour"`
+	Time            string `json:"time"`
+}
+
+// Tells if the user has admin role
+func isAdmin(ctx context.Context, login string) (bool, error) {
+	u, _, err := getUser(ctx, login)
+	if err != nil {
+		return false, err
+	}
+	return u != nil && u.Role == adminRole, nil
+}
+
+// Serves /admin/<tab>, session is checked by authMiddleware
+func adminHandle(w http.ResponseWriter, r *http.Request) {
+	sess := sessionOf(r.Context())
+	if sess == nil || sess.apiToken {
+		http.Error(w, "Admin pages are not available to api tokens", http.StatusForbidden)
+		return
+	}
+	ok, err := isAdmin(r.Context(), sess.login)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if !ok {
+		http.Error(w, "Only admins can see this page", http.StatusForbidden)
+		return
+	}
+
+	tab := strings.Trim(strings.TrimPrefix(r.URL.Path, adminPath), "/")
+	if tab == "" {
+		http.Redirect(w, r, adminPath+"/"+adminTabs[0], http.StatusSeeOther)
+		return
+	}
+	if tab == "stats.json" {
+		adminStatsHandle(w, r)
+		return
+	}
+	if !contains(adminTabs, tab) {
+		http.NotFound(w, r)
+		return
+	}
+
+	page := &adminPage{Tab: tab, Tabs: adminTabs, Login: sess.login, Roles: userRoles, Kinds: restrictionKinds, Decisions: reportDecisions, Query: r.FormValue("q"), CSRF: csrfToken(sess)}
+	code := http.StatusOK
+	if r.Method == http.MethodPost {
+		if isReadOnly(r.Context()) {
+			writeReadOnly(w)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Malformed form", http.StatusBadRequest)
+			return
+		}
+		if !validCSRF(r, sess) {
+			err := errs.New(errs.PermissionDenied, errs.ReasonBadCSRF, "Form is expired, reload the page")
+			audit(r.Context(), sess.login, tab+"/"+r.PostForm.Get("action"), formTarget(r.PostForm), formDetails(r.PostForm), err)
+			writeError(w, r, err)
+			return
+		}
+		page.Notice, err = adminAction(r, sess.login, tab)
+		audit(r.Context(), sess.login, tab+"/"+r.PostForm.Get("action"), formTarget(r.PostForm), formDetails(r.PostForm), err)
+		if code = errs.HTTPStatus(err); code >= http.StatusInternalServerError {
+			writeError(w, r, err)
+			return
+		} else if err != nil {
+			page.Notice, page.Error = "", status.Convert(err).Message()
+		}
+	}
+
+	if err := page.load(r); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(code)
+	err = tpl.ExecuteTemplate(w, "admin.gohtml", page)
+	if err != nil {
+		logs.Ctx(r.Context()).Error(err)
+	}
+}
+
+// Runs action of the form, returns notice for the page
+func adminAction(r *http.Request, admin, tab string) (string, error) {
+	ctx := r.Context()
+	action, login := r.PostForm.Get("action"), r.PostForm.Get("login")
+	logs.Ctx(ctx).Infof("Admin %s: %s %s", admin, action, login)
+	// Admin should not lock oneself out by mistake
+	if login == admin && (action == "disable" || action == "role" || action == "restrict") {
+		return "", errs.Invalid("login", "You can not change your own account")
+	}
+
+	switch tab + "/" + action {
+	case "users/disable":
+		recentSessions.forgetUser(login)
+		return "Disabled " + login, RedisAdapter.SetDisabled(ctx, login, true)
+	case "users/enable":
+		return "Enabled " + login, RedisAdapter.SetDisabled(ctx, login, false)
+	case "users/role":
+		role := r.PostForm.Get("role")
+		if !contains(userRoles, role) {
+			return "", errs.Invalid("role", "Unknown role")
+		}
+		return login + " is " + role + " now", RedisAdapter.SetRole(ctx, login, role)
+	case "users/logout":
+		recentSessions.forgetUser(login)
+		killed, err := RedisAdapter.KillSessions(ctx, login)
+		return "Ended " + strconv.Itoa(killed) + " sessions of " + login, err
+	case "users/restrict":
+		kind := r.PostForm.Get("kind")
+		if !contains(restrictionKinds, kind) {
+			return "", errs.Invalid("kind", "Unknown restriction")
+		}
+		seconds, err := restrictionSeconds(r.PostForm.Get("duration"))
+		if err != nil {
+			return "", err
+		}
+		_, err = RedisAdapter.SetRestriction(ctx, &redisrpc.Restriction{Login: login, Kind: kind, Reason: r.PostForm.Get("reason"), By: admin}, seconds)
+		if kind == restrictionBan {
+			recentSessions.forgetUser(login)
+		}
+		return login + " got " + kind, err
+	case "users/unrestrict":
+		return "Restriction of " + login + " is lifted", RedisAdapter.ClearRestriction(ctx, login)
+	case "rooms/create":
+		name := r.PostForm.Get("name")
+		if !roomNamePattern.MatchString(name) {
+			return "", errs.Invalid("name", "Name must be 1-32 lowercase letters, digits, '-' or '_'")
+		}
+		_, err := MongoAdapter.CreateRoom(ctx, &mongorpc.RoomInfo{
+			Name:      name,
+			Topic:     r.PostForm.Get("topic"),
+			CreatedBy: admin,
+			CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+		})
+		return "Created room " + name, err
+	case "rooms/topic":
+		name := r.PostForm.Get("name")
+		_, err := MongoAdapter.SetTopic(ctx, name, r.PostForm.Get("topic"))
+		return "Topic of " + name + " is changed", err
+	case "moderation/delete":
+		// Empty author lets admin delete any message
+		_, err := MongoAdapter.Delete(ctx, r.PostForm.Get("room"), r.PostForm.Get("id"), "")
+		if err == nil {
+			dropMentions(ctx, r.PostForm.Get("id"))
+		}
+		return "Message is deleted", err
+	case "reports/resolve":
+		seconds, err := restrictionSeconds(r.PostForm.Get("duration"))
+		if err != nil {
+			return "", err
+		}
+		decision := r.PostForm.Get("decision")
+		return "Report is resolved: " + decision, resolveReport(ctx, admin, r.PostForm.Get("id"), decision, seconds)
+	}
+	return "", errs.Invalid("action", "Unknown action")
+}
+
+// Parses duration of restriction form, 0 seconds (forever) if it is empty
+func restrictionSeconds(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < time.Second {
+		return 0, errs.Invalid("duration", "Duration must be like 30m or 24h")
+	}
+	return int64(duration / time.Second), nil
+}
+
+// Loads data of the section
+func (p *adminPage) load(r *http.Request) error {
+	ctx := r.Context()
+	var err error
+	switch p.Tab {
+	case "users":
+		if p.Users, err = RedisAdapter.ListUsers(ctx, p.Query); err != nil {
+			return err
+		}
+		var restrictions []*redisrpc.Restriction
+		restrictions, err = RedisAdapter.ListRestrictions(ctx)
+		p.Restrictions = make(map[string]*redisrpc.Restriction, len(restrictions))
+		for _, rs := range restrictions {
+			p.Restrictions[rs.Login] = rs
+		}
+	case "rooms":
+		p.Rooms, err = MongoAdapter.ListRooms(ctx)
+	case "moderation":
+		p.Room = roomOf(r)
+		if p.Rooms, err = MongoAdapter.ListRooms(ctx); err != nil {
+			return err
+		}
+		p.Messages, _, err = MongoAdapter.Read(ctx, p.Room, "", numChatMessages, "")
+		// Newest first, as moderators read it
+		for a, b := 0, len(p.Messages)-1; a < b; a, b = a+1, b-1 {
+			p.Messages[a], p.Messages[b] = p.Messages[b], p.Messages[a]
+		}
+	case "reports":
+		if p.Reports, err = MongoAdapter.ListReports(ctx, mongoservice.ReportOpen, numChatMessages); err != nil {
+			return err
+		}
+		p.Resolved, err = MongoAdapter.ListReports(ctx, mongoservice.ReportResolved, adminResolvedNumber)
+	case "stats":
+		p.Stats, err = collectStats(ctx)
+	case "logs":
+		// Log store is optional, page tells that it is not there
+		p.Logs, err = ClickhouseAdapter.Recent(ctx, adminLogsNumber, p.Query)
+		if err != nil {
+			logs.Ctx(ctx).Error("Logs are not available: ", err)
+			p.Error, err = "Log store is not available: "+status.Convert(err).Message(), nil
+		}
+	case "audit":
+		a := &clickhouserpc.ListAuditRequest{Actor: r.FormValue("actor"), Action: r.FormValue("action"), Target: r.FormValue("target")}
+		p.Audit = a
+		p.Events, err = ClickhouseAdapter.ListAudit(ctx, adminAuditNumber, a.Actor, a.Action, a.Target)
+		if err != nil {
+			logs.Ctx(ctx).Error("Audit log is not available: ", err)
+			p.Error, err = "Audit log is not available: "+status.Convert(err).Message(), nil
+		}
+	}
+	return err
+}
+
+// Answers with live statistics
+func adminStatsHandle(w http.ResponseWriter, r *http.Request) {
+	stats, err := collectStats(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+func collectStats(ctx context.Context) (*adminStats, error) {
+	sessions, err := RedisAdapter.ListSessions(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	stats := &adminStats{
+		Rooms:           activity.byRoom(whoWindow),
+		Sessions:        len(sessions),
+		MessagesPerHour: messagesPerHour.last(statsHours),
+		Time:            time.Now().Format("2006-01-02 15:04:05"),
+	}
+	online := make(map[string]bool)
+	for _, users := range stats.Rooms {
+		for _, u := range users {
+			online[u] = true
+		}
+	}
+	stats.Online = len(online)
+	return stats, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
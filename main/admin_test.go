@@ -0,0 +1,36 @@
+package main
+
+import (
+	"chat_room_go/utils/errs"
+	"testing"
+)
+
+func TestRestrictionSeconds(t *testing.T) {
+	tests := []struct {
+		value   string
+		seconds int64
+		invalid bool
+	}{
+		{"", 0, false},
+		{"1s", 1, false},
+		{"30m", 1800, false},
+		{"24h", 86400, false},
+		{"1h30m", 5400, false},
+		{"1.5s", 1, false},
+		{"500ms", 0, true},
+		{"0s", 0, true},
+		{"-1h", 0, true},
+		{"30", 0, true},
+		{"1d", 0, true},
+		{"forever", 0, true},
+	}
+	for _, tt := range tests {
+		seconds, err := restrictionSeconds(tt.value)
+		if invalid := errs.Field(err) == "duration"; invalid != tt.invalid {
+			t.Errorf("restrictionSeconds(%q): error %v, want invalid %v", tt.value, err, tt.invalid)
+		}
+		if seconds != tt.seconds {
+			t.Errorf("restrictionSeconds(%q) = %d, want %d", tt.value, seconds, tt.seconds)
+		}
+	}
+}
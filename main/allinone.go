@@ -0,0 +1,123 @@
+// All-in-one mode: mongodb, redis and clickhouse microservices are hosted in-process
+// They talk to main over in-memory listeners, no TLS and tokens needed
+
+package main
+
+import (
+	mmw "chat_room_go/microservices"
+	clickhouseservice "chat_room_go/microservices/clickhouse"
+	mongoservice "chat_room_go/microservices/mongodb"
+	redisservice "chat_room_go/microservices/redis"
+	"chat_room_go/utils/logs"
+	"context"
+	"net"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Closers of in-process microservices: flush caches, close db pools and loggers
+var serviceClosers = map[string]func() error{
+	mongoService:      mongoservice.Close,
+	redisService:      redisservice.Close,
+	clickhouseService: clickhouseservice.Close,
+}
+
+// Size of in-memory buffer of each listener
+const bufConnSize = 1024 * 1024
+
+// Names of in-process microservices
+const (
+	mongoService      = "mongodb"
+	redisService      = "redis"
+	clickhouseService = "clickhouse"
+)
+
+// Keeps in-process grpc servers and their listeners
+type inProcessServices struct {
+	servers   map[string]*grpc.Server
+	listeners map[string]*bufconn.Listener
+	// Background work of services: health checks, canceled on stop
+	background     context.Context
+	stopBackground context.CancelFunc
+}
+
+// Starts all microservices in-process
+func startInProcessServices() *inProcessServices {
+	s := &inProcessServices{
+		servers:   make(map[string]*grpc.Server),
+		listeners: make(map[string]*bufconn.Listener),
+	}
+	s.background, s.stopBackground = context.WithCancel(context.Background())
+	s.serve(mongoService, mongoservice.Register)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := mongoservice.CreateIndexes(ctx); err != nil {
+			logs.Logger.Errorf("Indexes of mongodb are not created: %s", err)
+		}
+	}()
+	s.serve(redisService, redisservice.Register)
+	s.serve(clickhouseService, clickhouseservice.Register)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := clickhouseservice.PrepareAudit(ctx); err != nil {
+			logs.Logger.Errorf("Audit table of clickhouse is not created: %s", err)
+		}
+	}()
+
+	return s
+}
+
+// Creates grpc server with registered services and serves it on the bufconn listener
+func (s *inProcessServices) serve(name string, register func(context.Context, *grpc.Server)) {
+	lis := bufconn.Listen(bufConnSize)
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(mmw.TracingInterceptor, mmw.LogInterceptor, mmw.MetricsInterceptor, mmw.ErrorInterceptor)))
+	register(s.background, server)
+
+	s.servers[name] = server
+	s.listeners[name] = lis
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			logs.Logger.Errorf("In-process %s service stopped: %s", name, err)
+		}
+	}()
+	logs.Logger.Infof("Started in-process %s service", name)
+}
+
+// Returns dial options to reach in-process service by name
+func (s *inProcessServices) dialOptions(name string) []grpc.DialOption {
+	lis := s.listeners[name]
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	}
+}
+
+// Stops in-process servers gracefully and releases resources of microservices until ctx deadline
+func (s *inProcessServices) stop(ctx context.Context) {
+	s.stopBackground()
+	for _, server := range s.servers {
+		mmw.GracefulStop(ctx, server)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for name, closeService := range serviceClosers {
+			if err := closeService(); err != nil {
+				logs.Logger.Errorf("Error during %s service cleanup: %s", name, err)
+			}
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logs.Logger.Warn("In-process services cleanup timed out")
+	}
+}
@@ -0,0 +1,259 @@
+// Versioned JSON api for third-party clients: router, envelopes, errors and pagination
+// Every route is described in apiRoutes, the same description generates OpenAPI spec
+
+package main
+
+import (
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Prefix of all api routes
+const apiPrefix = "/api/v1"
+
+// Max size of api request body
+const maxAPIBody = 1 << 20
+
+// Envelope of every api response: data on success, error otherwise
+type apiResponse struct {
+	Data       interface{}    `json:"data,omitempty"`
+	Pagination *apiPagination `json:"pagination,omitempty"`
+	Error      *apiError      `json:"error,omitempty"`
+	RequestID  string         `json:"request_id"`
+}
+
+// Error of api request
+type apiError struct {
+	// Grpc code name, e.g. NotFound
+	Code string `json:"code"`
+	// Machine readable reason, e.g. ROOM_NOT_FOUND
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message"`
+	// Wrong field of request
+	Field string `json:"field,omitempty"`
+}
+
+// Page of a list, next page is requested with before=next
+type apiPagination struct {
+	Limit   int    `json:"limit"`
+	HasMore bool   `json:"has_more"`
+	Next    string `json:"next,omitempty"`
+}
+
+// Query parameter of a route
+type apiParam struct {
+	Name        string
+	Description string
+	Type        string
+}
+
+// Request to api handler: http request, path parameters and session of the caller (for routes with auth)
+type apiRequest struct {
+	*http.Request
+	params  map[string]string
+	session *session
+}
+
+// Returns path parameter
+func (r *apiRequest) param(name string) string {
+	return r.params[name]
+}
+
+// Decodes json body of the request into v
+func (r *apiRequest) decode(v interface{}) error {
+	err := json.NewDecoder(io.LimitReader(r.Body, maxAPIBody)).Decode(v)
+	if err != nil {
+		return errs.Invalid("body", "Body is not valid json: "+err.Error())
+	}
+	return nil
+}
+
+// Api handler returns data of the response, pagination for lists, or error
+type apiHandler func(r *apiRequest) (interface{}, *apiPagination, error)
+
+// Route of api, also describes it for OpenAPI spec
+type apiRoute struct {
+	Method string
+	// Path relative to apiPrefix, parameters in braces: /rooms/{room}
+	Path    string
+	Tag     string
+	Summary string
+	// Route requires session (bearer token or cookie)
+	Auth bool
+	// Scope that personal api token needs for the route, empty if route is for login sessions only
+	Scope string
+	Query []apiParam
+	// Example values of request body and response data types, nil if there is none
+	Body     interface{}
+	Response interface{}
+	// Status of successful response, 200 if not set
+	Status  int
+	handler apiHandler
+}
+
+// Splits path into segments, {name} segments are parameters
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// Matches path against route path, returns path parameters
+func (rt *apiRoute) match(segments []string) (map[string]string, bool) {
+	pattern := splitPath(rt.Path)
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for n, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if segments[n] == "" {
+				return nil, false
+			}
+			params[p[1:len(p)-1]] = segments[n]
+		} else if p != segments[n] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// Router of api, serves everything under apiPrefix
+type apiRouter struct {
+	routes []apiRoute
+}
+
+func (a *apiRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(strings.TrimPrefix(r.URL.Path, apiPrefix))
+	methodMismatch := false
+	for n := range a.routes {
+		rt := &a.routes[n]
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.Method != r.Method {
+			methodMismatch = true
+			continue
+		}
+		a.serveRoute(w, r, rt, params)
+		return
+	}
+
+	if methodMismatch {
+		writeAPIJSON(w, r, http.StatusMethodNotAllowed, apiResponse{Error: &apiError{Code: "MethodNotAllowed", Message: "Method is not allowed"}})
+		return
+	}
+	writeAPIJSON(w, r, http.StatusNotFound, apiResponse{Error: &apiError{Code: codes.NotFound.String(), Message: "No such api route"}})
+}
+
+// Checks session if route requires it, calls handler and writes envelope
+func (a *apiRouter) serveRoute(w http.ResponseWriter, r *http.Request, rt *apiRoute, params map[string]string) {
+	req := &apiRequest{Request: r, params: params}
+	if rt.Auth {
+		sess, err := getSession(r)
+		if err != nil {
+			writeAPIError(w, r, err)
+			return
+		}
+		if sess == nil {
+			writeAPIError(w, r, errs.New(errs.Unauthenticated, errs.ReasonUnauthenticated, "Bearer token or session cookie is required"))
+			return
+		}
+		if !sess.allows(rt.Scope) {
+			writeAPIError(w, r, errScope(rt.Scope))
+			return
+		}
+		if !sess.readOnly {
+			if err := checkBan(r.Context(), sess.login); err != nil {
+				writeAPIError(w, r, err)
+				return
+			}
+		}
+		if sess.readOnly {
+			w.Header().Set(readOnlyHeader, "true")
+			if r.Method != http.MethodGet {
+				writeAPIError(w, r, errs.New(errs.Unavailable, errs.ReasonDatabaseUnavailable, readOnlyMessage))
+				return
+			}
+		}
+		req.session = sess
+	}
+
+	data, page, err := rt.handler(req)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	code := rt.Status
+	if code == 0 {
+		code = http.StatusOK
+	}
+	if code == http.StatusNoContent {
+		w.WriteHeader(code)
+		return
+	}
+	writeAPIJSON(w, r, code, apiResponse{Data: data, Pagination: page})
+}
+
+// Writes error envelope with http status of the error, details of internal errors are only logged
+func writeAPIError(w http.ResponseWriter, r *http.Request, err error) {
+	code := errs.HTTPStatus(err)
+	st, _ := status.FromError(err)
+	message := st.Message()
+	var e *errs.Error
+	if errors.As(err, &e) {
+		message = e.Message
+	}
+	if code >= http.StatusInternalServerError {
+		logs.Ctx(r.Context()).Errorf("Api error (%s): %s", errs.Reason(err), err)
+		message = http.StatusText(code)
+		if code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout {
+			w.Header().Set("Retry-After", strconv.Itoa(config.Config.Resilience.BreakerCooldown))
+			message = unavailableMessage
+			if isReadOnly(r.Context()) || w.Header().Get(readOnlyHeader) != "" {
+				message = readOnlyMessage
+			}
+		}
+	}
+	writeAPIJSON(w, r, code, apiResponse{Error: &apiError{
+		Code:    st.Code().String(),
+		Reason:  errs.Reason(err),
+		Message: message,
+		Field:   errs.Field(err),
+	}})
+}
+
+// Writes envelope as json
+func writeAPIJSON(w http.ResponseWriter, r *http.Request, code int, resp apiResponse) {
+	resp.RequestID = logs.RequestID(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logs.Ctx(r.Context()).Error("Error during api response encoding: ", err)
+	}
+}
+
+// Parses limit query parameter, default if not set, capped by max
+func parseLimit(r *apiRequest, def, max int) (int, error) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return def, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, errs.Invalid("limit", "limit must be a positive number")
+	}
+	if limit > max {
+		limit = max
+	}
+	return limit, nil
+}
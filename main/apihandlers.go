@@ -0,0 +1,484 @@
+// Routes and handlers of api v1
+
+package main
+
+import (
+	"chat_room_go/main/models"
+	mongorpc "chat_room_go/microservices/mongodb/pb"
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// Default page size of messages list
+const defaultAPILimit = 50
+
+// Allowed names of rooms
+var roomNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// Message as api returns it
+type messageDTO struct {
+	ID     string `json:"id"`
+	Room   string `json:"room"`
+	Author string `json:"author"`
+	// Display name of the author at the time of posting
+	Nick   string `json:"nick,omitempty"`
+	Text   string `json:"text"`
+	Time   string `json:"time"`
+	Bot    bool   `json:"bot,omitempty"`
+	Edited string `json:"edited,omitempty"`
+	// Reactions sorted by emoji
+	Reactions []reactionDTO `json:"reactions,omitempty"`
+	// Id of the thread, if the message is a reply
+	Parent string `json:"parent,omitempty"`
+	// Replies of the thread and time of the last one, for messages of the room stream
+	Replies   int32  `json:"replies,omitempty"`
+	LastReply string `json:"last_reply,omitempty"`
+}
+
+// Thread as api returns it: the first message with number of replies and a page of replies
+type threadDTO struct {
+	Parent  messageDTO   `json:"parent"`
+	Replies []messageDTO `json:"replies"`
+}
+
+// Room as api returns it
+type roomDTO struct {
+	Name      string `json:"name"`
+	Topic     string `json:"topic,omitempty"`
+	CreatedBy string `json:"created_by,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// User as api returns it, without password
+type userDTO struct {
+	Login     string `json:"login"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+	Nick      string `json:"nick,omitempty"`
+}
+
+// Bearer token of new session
+type tokenDTO struct {
+	Token     string `json:"token"`
+	TokenType string `json:"token_type"`
+	// Seconds until session expires
+	ExpiresIn int `json:"expires_in"`
+}
+
+// Personal api token as api returns it, without secret
+type apiTokenDTO struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"created_at"`
+	LastUsed  string   `json:"last_used,omitempty"`
+}
+
+// Just created token with its secret
+type newAPITokenDTO struct {
+	Token  apiTokenDTO `json:"token"`
+	Secret string      `json:"secret"`
+}
+
+type signupBody struct {
+	Login     string `json:"login"`
+	Password  string `json:"password"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type loginBody struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type createRoomBody struct {
+	Name  string `json:"name"`
+	Topic string `json:"topic"`
+}
+
+type createAPITokenBody struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type postMessageBody struct {
+	Text string `json:"text"`
+	// Id of the message to reply to, the reply goes to its thread
+	Parent string `json:"parent,omitempty"`
+}
+
+type editMessageBody struct {
+	Text string `json:"text"`
+}
+
+// All routes of api v1, relative to apiPrefix
+var apiRoutes = []apiRoute{
+	{Method: http.MethodPost, Path: "/auth/signup", Tag: "auth", Summary: "Create user and return bearer token",
+		Body: signupBody{}, Response: tokenDTO{}, Status: http.StatusCreated, handler: apiSignup},
+	{Method: http.MethodPost, Path: "/auth/login", Tag: "auth", Summary: "Check login and password, return bearer token",
+		Body: loginBody{}, Response: tokenDTO{}, handler: apiLogin},
+	{Method: http.MethodPost, Path: "/auth/logout", Tag: "auth", Summary: "End session of the token", Auth: true,
+		Status: http.StatusNoContent, handler: apiLogout},
+	{Method: http.MethodGet, Path: "/users/me", Tag: "users", Summary: "Current user", Auth: true, Scope: scopeRead,
+		Response: userDTO{}, handler: apiCurrentUser},
+	{Method: http.MethodGet, Path: "/users/{login}", Tag: "users", Summary: "User by login", Auth: true, Scope: scopeRead,
+		Response: userDTO{}, handler: apiGetUser},
+	{Method: http.MethodGet, Path: "/rooms", Tag: "rooms", Summary: "All rooms sorted by name", Auth: true, Scope: scopeRead,
+		Response: []roomDTO{}, handler: apiListRooms},
+	{Method: http.MethodPost, Path: "/rooms", Tag: "rooms", Summary: "Create room", Auth: true, Scope: scopeWrite,
+		Body: createRoomBody{}, Response: roomDTO{}, Status: http.StatusCreated, handler: apiCreateRoom},
+	{Method: http.MethodGet, Path: "/rooms/{room}", Tag: "rooms", Summary: "Room by name", Auth: true, Scope: scopeRead,
+		Response: roomDTO{}, handler: apiGetRoom},
+	{Method: http.MethodGet, Path: "/rooms/{room}/messages", Tag: "messages", Summary: "Messages of the room, newest page first, chronological inside page", Auth: true, Scope: scopeRead,
+		Query: []apiParam{
+			{Name: "limit", Description: "Page size, 50 by default", Type: "integer"},
+			{Name: "before", Description: "Id of message, only older messages are returned (pagination.next of previous page)", Type: "string"},
+		},
+		Response: []messageDTO{}, handler: apiListMessages},
+	{Method: http.MethodPost, Path: "/rooms/{room}/messages", Tag: "messages", Summary: "Post message to the room", Auth: true, Scope: scopeWrite,
+		Body: postMessageBody{}, Response: messageDTO{}, Status: http.StatusCreated, handler: apiPostMessage},
+	{Method: http.MethodPatch, Path: "/rooms/{room}/messages/{id}", Tag: "messages", Summary: "Edit text of own message", Auth: true, Scope: scopeWrite,
+		Body: editMessageBody{}, Response: messageDTO{}, handler: apiEditMessage},
+	{Method: http.MethodGet, Path: "/rooms/{room}/messages/{id}/thread", Tag: "messages", Summary: "Thread of the message: its first message and replies, newest page first, chronological inside page", Auth: true, Scope: scopeRead,
+		Query: []apiParam{
+			{Name: "limit", Description: "Page size, 50 by default", Type: "integer"},
+			{Name: "before", Description: "Id of reply, only older replies are returned (pagination.next of previous page)", Type: "string"},
+		},
+		Response: threadDTO{}, handler: apiGetThread},
+	{Method: http.MethodDelete, Path: "/rooms/{room}/messages/{id}", Tag: "messages", Summary: "Delete own message", Auth: true, Scope: scopeWrite,
+		Status: http.StatusNoContent, handler: apiDeleteMessage},
+	{Method: http.MethodPost, Path: "/rooms/{room}/messages/{id}/reports", Tag: "messages", Summary: "Report message to moderators, once per message", Auth: true, Scope: scopeWrite,
+		Body: createReportBody{}, Response: reportDTO{}, Status: http.StatusCreated, handler: apiCreateReport},
+	{Method: http.MethodPost, Path: "/rooms/{room}/messages/{id}/reactions", Tag: "messages", Summary: "Add reaction of current user to message or take it back", Auth: true, Scope: scopeWrite,
+		Body: toggleReactionBody{}, Response: messageDTO{}, handler: apiToggleReaction},
+	{Method: http.MethodGet, Path: "/rooms/{room}/messages/{id}/readers", Tag: "messages", Summary: "Users, who have read the room up to the message or further, sorted by login", Auth: true, Scope: scopeRead,
+		Response: []readerDTO{}, handler: apiListReaders},
+	{Method: http.MethodPut, Path: "/rooms/{room}/read", Tag: "rooms", Summary: "Mark messages of the room read up to the message, marker does not go back", Auth: true, Scope: scopeWrite,
+		Body: markReadBody{}, Response: unreadDTO{}, handler: apiMarkRead},
+	{Method: http.MethodGet, Path: "/unread", Tag: "rooms", Summary: "Unread messages of current user in every room", Auth: true, Scope: scopeRead,
+		Response: []unreadDTO{}, handler: apiListUnread},
+	{Method: http.MethodGet, Path: "/mentions", Tag: "mentions", Summary: "Mentions of current user, newest first", Auth: true, Scope: scopeRead,
+		Query: []apiParam{
+			{Name: "limit", Description: "Number of mentions, 50 by default", Type: "integer"},
+			{Name: "unread", Description: "Only unread mentions", Type: "boolean"},
+		},
+		Response: []mentionDTO{}, handler: apiListMentions},
+	{Method: http.MethodGet, Path: "/mentions/count", Tag: "mentions", Summary: "Number of unread mentions of current user", Auth: true, Scope: scopeRead,
+		Response: mentionsCountDTO{}, handler: apiCountMentions},
+	{Method: http.MethodPost, Path: "/mentions/read", Tag: "mentions", Summary: "Mark mentions of current user read, all of them if no ids given", Auth: true, Scope: scopeWrite,
+		Body: markMentionsBody{}, Response: mentionsCountDTO{}, handler: apiMarkMentionsRead},
+	{Method: http.MethodGet, Path: "/tokens", Tag: "tokens", Summary: "Personal api tokens of current user", Auth: true,
+		Response: []apiTokenDTO{}, handler: apiListTokens},
+	{Method: http.MethodPost, Path: "/tokens", Tag: "tokens", Summary: "Create personal api token, secret is returned only once", Auth: true,
+		Body: createAPITokenBody{}, Response: newAPITokenDTO{}, Status: http.StatusCreated, handler: apiCreateToken},
+	{Method: http.MethodDelete, Path: "/tokens/{id}", Tag: "tokens", Summary: "Revoke personal api token", Auth: true,
+		Status: http.StatusNoContent, handler: apiRevokeToken},
+	{Method: http.MethodGet, Path: "/hooks", Tag: "hooks", Summary: "Incoming webhooks of current user", Auth: true,
+		Response: []incomingHookDTO{}, handler: apiListHooks},
+	{Method: http.MethodPost, Path: "/hooks", Tag: "hooks", Summary: "Create incoming webhook, its url is returned only once", Auth: true,
+		Body: createIncomingHookBody{}, Response: incomingHookDTO{}, Status: http.StatusCreated, handler: apiCreateHook},
+	{Method: http.MethodDelete, Path: "/hooks/{id}", Tag: "hooks", Summary: "Delete incoming webhook", Auth: true,
+		Status: http.StatusNoContent, handler: apiDeleteHook},
+	{Method: http.MethodGet, Path: "/outgoing-hooks", Tag: "hooks", Summary: "Outgoing webhooks of current user", Auth: true,
+		Response: []outgoingHookDTO{}, handler: apiListOutgoingHooks},
+	{Method: http.MethodPost, Path: "/outgoing-hooks", Tag: "hooks", Summary: "Register endpoint for events, user.signup is for admins only", Auth: true,
+		Body: createOutgoingHookBody{}, Response: outgoingHookDTO{}, Status: http.StatusCreated, handler: apiCreateOutgoingHook},
+	{Method: http.MethodDelete, Path: "/outgoing-hooks/{id}", Tag: "hooks", Summary: "Delete outgoing webhook", Auth: true,
+		Status: http.StatusNoContent, handler: apiDeleteOutgoingHook},
+	{Method: http.MethodGet, Path: "/outgoing-hooks/dead-letters", Tag: "hooks", Summary: "Deliveries to hooks of current user, that failed all attempts", Auth: true,
+		Response: []deadLetterDTO{}, handler: apiListDeadLetters},
+}
+
+func apiSignup(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body signupBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if body.Login == "" {
+		return nil, nil, errs.Invalid("login", "login is required")
+	}
+	if body.Password == "" {
+		return nil, nil, errs.Invalid("password", "password is required")
+	}
+	_, found, err := getUser(r.Context(), body.Login)
+	if err != nil {
+		return nil, nil, err
+	}
+	if found {
+		err := errs.New(errs.AlreadyExists, errs.ReasonUserExists, "Username already taken")
+		audit(r.Context(), body.Login, auditSignup, body.Login, "api", err)
+		return nil, nil, err
+	}
+	pass, err := hashPassword(body.Password)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = RedisAdapter.Write(r.Context(), models.User{Login: body.Login, Fname: body.FirstName, Lname: body.LastName, Pass: pass, Role: "user"})
+	audit(r.Context(), body.Login, auditSignup, body.Login, "api role=user", err)
+	if err != nil {
+		return nil, nil, err
+	}
+	return apiToken(r, body.Login)
+}
+
+func apiLogin(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body loginBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	user, ok, err := checkPassword(r.Context(), body.Login, body.Password)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		audit(r.Context(), body.Login, auditLogin, body.Login, "api", errWrongPassword)
+		return nil, nil, errWrongPassword
+	}
+	if err := checkBan(r.Context(), user.Login); err != nil {
+		audit(r.Context(), user.Login, auditLogin, user.Login, "api", err)
+		return nil, nil, err
+	}
+	token, _, err := apiToken(r, user.Login)
+	audit(r.Context(), user.Login, auditLogin, user.Login, "api", err)
+	return token, nil, err
+}
+
+// Creates session and returns it as bearer token
+func apiToken(r *apiRequest, login string) (interface{}, *apiPagination, error) {
+	id, err := newSession(r.Context(), login)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokenDTO{Token: id, TokenType: "Bearer", ExpiresIn: sessionLength}, nil, nil
+}
+
+func apiLogout(r *apiRequest) (interface{}, *apiPagination, error) {
+	if err := RedisAdapter.DeleteSession(r.Context(), r.session.id); err != nil {
+		return nil, nil, err
+	}
+	recentSessions.forget(r.session.id)
+	audit(r.Context(), r.session.login, auditLogout, r.session.login, "api", nil)
+	return nil, nil, nil
+}
+
+func apiCurrentUser(r *apiRequest) (interface{}, *apiPagination, error) {
+	return userByLogin(r, r.session.login)
+}
+
+func apiGetUser(r *apiRequest) (interface{}, *apiPagination, error) {
+	return userByLogin(r, r.param("login"))
+}
+
+func userByLogin(r *apiRequest, login string) (interface{}, *apiPagination, error) {
+	u, found, err := getUser(r.Context(), login)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, errs.New(errs.NotFound, errs.ReasonUserNotFound, "User not found")
+	}
+	return userDTO{Login: u.Login, FirstName: u.Fname, LastName: u.Lname, Role: u.Role, Nick: u.Nick}, nil, nil
+}
+
+func apiListRooms(r *apiRequest) (interface{}, *apiPagination, error) {
+	rooms, err := MongoAdapter.ListRooms(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]roomDTO, 0, len(rooms))
+	for _, room := range rooms {
+		res = append(res, toRoomDTO(room))
+	}
+	return res, nil, nil
+}
+
+func apiCreateRoom(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body createRoomBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if !roomNamePattern.MatchString(body.Name) {
+		return nil, nil, errs.Invalid("name", "name must be 1-32 lowercase letters, digits, '-' or '_'")
+	}
+	room, err := MongoAdapter.CreateRoom(r.Context(), &mongorpc.RoomInfo{
+		Name:      body.Name,
+		Topic:     body.Topic,
+		CreatedBy: r.session.login,
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return toRoomDTO(room), nil, nil
+}
+
+func apiGetRoom(r *apiRequest) (interface{}, *apiPagination, error) {
+	room, err := MongoAdapter.GetRoom(r.Context(), r.param("room"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return toRoomDTO(room), nil, nil
+}
+
+func apiListMessages(r *apiRequest) (interface{}, *apiPagination, error) {
+	limit, err := parseLimit(r, defaultAPILimit, numChatMessages)
+	if err != nil {
+		return nil, nil, err
+	}
+	room := r.param("room")
+	if _, err := MongoAdapter.GetRoom(r.Context(), room); err != nil {
+		return nil, nil, err
+	}
+	messages, hasMore, err := MongoAdapter.Read(r.Context(), room, r.URL.Query().Get("before"), limit, r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]messageDTO, 0, len(messages))
+	for _, m := range messages {
+		res = append(res, toMessageDTO(m))
+	}
+	page := &apiPagination{Limit: limit, HasMore: hasMore}
+	if hasMore && len(res) > 0 {
+		page.Next = res[0].ID
+	}
+	return res, page, nil
+}
+
+func apiGetThread(r *apiRequest) (interface{}, *apiPagination, error) {
+	limit, err := parseLimit(r, defaultAPILimit, numChatMessages)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, replies, hasMore, err := MongoAdapter.Thread(r.Context(), r.param("room"), r.param("id"), r.URL.Query().Get("before"), limit, r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := threadDTO{Parent: toMessageDTO(parent), Replies: make([]messageDTO, 0, len(replies))}
+	for _, m := range replies {
+		res.Replies = append(res.Replies, toMessageDTO(m))
+	}
+	page := &apiPagination{Limit: limit, HasMore: hasMore}
+	if hasMore && len(res.Replies) > 0 {
+		page.Next = res.Replies[0].ID
+	}
+	return res, page, nil
+}
+
+func apiPostMessage(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body postMessageBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if body.Text == "" {
+		return nil, nil, errs.Invalid("text", "text is required")
+	}
+	if err := checkMute(r.Context(), r.session.login); err != nil {
+		return nil, nil, err
+	}
+	room := r.param("room")
+	if _, err := MongoAdapter.GetRoom(r.Context(), room); err != nil {
+		return nil, nil, err
+	}
+	m := models.ChatMessage{Time: time.Now().Format("2006-01-02 15:04:05"), Name: r.session.login, Nick: nickOf(r.Context(), r.session.login), Message: body.Text, Room: room, Parent: body.Parent}
+	if err := filterMessage(r.Context(), &m, false); err != nil {
+		return nil, nil, err
+	}
+	stored, err := MongoAdapter.Write(r.Context(), m)
+	if err != nil {
+		return nil, nil, err
+	}
+	countMessage()
+	notifyMentions(r.Context(), stored)
+	return toMessageDTO(stored), nil, nil
+}
+
+func apiEditMessage(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body editMessageBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if body.Text == "" {
+		return nil, nil, errs.Invalid("text", "text is required")
+	}
+	if err := checkMute(r.Context(), r.session.login); err != nil {
+		return nil, nil, err
+	}
+	m := models.ChatMessage{Name: r.session.login, Message: body.Text, Room: r.param("room")}
+	if err := filterMessage(r.Context(), &m, true); err != nil {
+		return nil, nil, err
+	}
+	edited, err := MongoAdapter.Edit(r.Context(), m.Room, r.param("id"), m.Name, m.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return toMessageDTO(edited), nil, nil
+}
+
+func apiDeleteMessage(r *apiRequest) (interface{}, *apiPagination, error) {
+	_, err := MongoAdapter.Delete(r.Context(), r.param("room"), r.param("id"), r.session.login)
+	if err == nil {
+		dropMentions(r.Context(), r.param("id"))
+	}
+	audit(r.Context(), r.session.login, auditDeleteMessage, r.param("id"), "room="+r.param("room"), err)
+	return nil, nil, err
+}
+
+func apiListTokens(r *apiRequest) (interface{}, *apiPagination, error) {
+	tokens, err := RedisAdapter.ListTokens(r.Context(), r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]apiTokenDTO, 0, len(tokens))
+	for _, t := range tokens {
+		res = append(res, toAPITokenDTO(t))
+	}
+	return res, nil, nil
+}
+
+func apiCreateToken(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body createAPITokenBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if err := validateToken(body.Name, body.Scopes); err != nil {
+		return nil, nil, err
+	}
+	token, secret, err := RedisAdapter.CreateToken(r.Context(), r.session.login, body.Name, body.Scopes)
+	audit(r.Context(), r.session.login, auditCreateToken, token.GetId(), tokenDetails(body.Name, body.Scopes), err)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newAPITokenDTO{Token: toAPITokenDTO(token), Secret: secret}, nil, nil
+}
+
+func apiRevokeToken(r *apiRequest) (interface{}, *apiPagination, error) {
+	err := RedisAdapter.RevokeToken(r.Context(), r.session.login, r.param("id"))
+	if err == nil {
+		recentSessions.forgetToken(r.param("id"))
+	}
+	audit(r.Context(), r.session.login, auditRevokeToken, r.param("id"), "", err)
+	return nil, nil, err
+}
+
+func toAPITokenDTO(t *redisrpc.TokenInfo) apiTokenDTO {
+	return apiTokenDTO{ID: t.Id, Name: t.Name, Scopes: t.Scopes, CreatedAt: t.CreatedAt, LastUsed: t.LastUsed}
+}
+
+func toRoomDTO(room *mongorpc.RoomInfo) roomDTO {
+	return roomDTO{Name: room.Name, Topic: room.Topic, CreatedBy: room.CreatedBy, CreatedAt: room.CreatedAt}
+}
+
+// Messages written before rooms appeared have no room, they belong to default one
+func toMessageDTO(m *mongorpc.MessageInfo) messageDTO {
+	room := m.Room
+	if room == "" {
+		room = defaultRoom
+	}
+	return messageDTO{ID: m.Id, Room: room, Author: m.Name, Nick: m.Nick, Text: m.Message, Time: m.Time, Bot: m.Bot, Edited: m.Edited,
+		Reactions: toReactionDTOs(m.Reactions), Parent: m.Parent, Replies: m.Replies, LastReply: m.LastReply}
+}
@@ -0,0 +1,187 @@
+// Audit log: security and admin events go to their own table of clickhouse microservice, admins read it on audit page
+// Unlike logs, events are typed: who did what to whom, from which address and with what outcome
+// Events are sent in background, so slow clickhouse does not slow down requests
+
+package main
+
+import (
+	clickhouserpc "chat_room_go/microservices/clickhouse/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Outcomes of audited actions
+const (
+	auditSuccess = "success"
+	// Wrong password, taken login and other mistakes of the user
+	auditFailure = "failure"
+	// Action is not allowed, e.g. login of banned user
+	auditDenied = "denied"
+	// Chat failed to do it
+	auditError = "error"
+)
+
+// Audited actions of users, actions of admin pages are "<tab>/<action>", e.g. "users/role"
+const (
+	auditSignup        = "signup"
+	auditLogin         = "login"
+	auditLogout        = "logout"
+	auditDeleteMessage = "messages/delete"
+	auditCreateToken   = "tokens/create"
+	auditRevokeToken   = "tokens/revoke"
+)
+
+// Fields of admin forms, that go to details of admin events
+var auditFormFields = []string{"role", "kind", "duration", "reason", "decision", "author", "room", "topic"}
+
+// Events waiting to be sent, events are dropped when queue is full
+const auditQueueSize = 1000
+
+// Deadline of sending one event
+const auditTimeout = 5 * time.Second
+
+var auditFailures = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "chat_audit_failures_total",
+	Help: "Number of audit events, that clickhouse did not store",
+})
+
+var auditQueue = struct {
+	events chan *clickhouserpc.AuditEvent
+	stop   chan struct{}
+	done   chan struct{}
+}{
+	events: make(chan *clickhouserpc.AuditEvent, auditQueueSize),
+	stop:   make(chan struct{}),
+	done:   make(chan struct{}),
+}
+
+// Sends queued events to clickhouse until stopAudit, then sends the ones left in queue
+func startAudit() {
+	go func() {
+		defer close(auditQueue.done)
+		for {
+			select {
+			case e := <-auditQueue.events:
+				sendAudit(e)
+			case <-auditQueue.stop:
+				for {
+					select {
+					case e := <-auditQueue.events:
+						sendAudit(e)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Waits until queued events are sent or ctx is done
+func stopAudit(ctx context.Context) {
+	close(auditQueue.stop)
+	select {
+	case <-auditQueue.done:
+	case <-ctx.Done():
+		logs.Logger.Warnf("Audit events are not sent before shutdown: %d", len(auditQueue.events))
+	}
+}
+
+func sendAudit(e *clickhouserpc.AuditEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), auditTimeout)
+	defer cancel()
+	if err := ClickhouseAdapter.Record(ctx, e); err != nil {
+		auditFailures.Inc()
+		logs.Logger.Errorf("Audit event is not stored \"%s\": %v", err, e)
+	}
+}
+
+type clientIPKey struct{}
+
+// Returns context with address of the client, see requestIDMiddleware
+func withClientIP(ctx context.Context, r *http.Request) context.Context {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// Returns address of the client of the request, empty outside of requests
+func clientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// Queues audit event, outcome follows from err of the action, time is of the action, not of a late delivery
+// Audit failure does not fail the action, the event goes to logs instead
+func audit(ctx context.Context, actor, action, target, details string, err error) {
+	e := &clickhouserpc.AuditEvent{
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Ip:        clientIP(ctx),
+		Outcome:   auditOutcome(err),
+		Details:   details,
+		RequestId: logs.RequestID(ctx),
+		Time:      time.Now().Format("2006-01-02 15:04:05"),
+	}
+	if err != nil && e.Details == "" {
+		e.Details = status.Convert(err).Message()
+	}
+	select {
+	case auditQueue.events <- e:
+	default:
+		auditFailures.Inc()
+		logs.Ctx(ctx).Errorf("Audit queue is full, event is not stored: %v", e)
+	}
+}
+
+func auditOutcome(err error) string {
+	if err == nil {
+		return auditSuccess
+	}
+	if status.Code(err) == codes.PermissionDenied {
+		return auditDenied
+	}
+	if errs.HTTPStatus(err) < http.StatusInternalServerError {
+		return auditFailure
+	}
+	return auditError
+}
+
+// Returns what admin form acts on: user, message, report or room
+func formTarget(form url.Values) string {
+	for _, name := range []string{"login", "id", "name"} {
+		if value := form.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Joins set fields of the form as "name=value" for details of admin events
+func formDetails(form url.Values) string {
+	var details []string
+	for _, name := range auditFormFields {
+		if value := form.Get(name); value != "" {
+			details = append(details, name+"="+value)
+		}
+	}
+	return strings.Join(details, " ")
+}
+
+func tokenDetails(name string, scopes []string) string {
+	return "name=" + name + " scopes=" + strings.Join(scopes, ",")
+}
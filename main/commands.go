@@ -0,0 +1,332 @@
+// Slash commands: messages of the chat page, that start with "/", are run as commands instead of being posted
+// Command may post message to the room on behalf of the caller and reply privately, only to the caller
+// "//text" is posted as usual message "/text"
+
+package main
+
+import (
+	"chat_room_go/main/models"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Response header with name of the command, that handled the message, body of response is private reply
+const commandHeader = "X-Chat-Command"
+
+// Users, that polled or posted to the room within this time, are listed by /who
+const whoWindow = 5 * time.Minute
+
+// Limits of arguments of built-in commands
+const (
+	maxNick  = 32
+	maxTopic = 200
+)
+
+var commandNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+var commandsRun = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "chat_commands_total",
+	Help: "Number of slash commands run by users, by command name",
+}, []string{"command"})
+
+// Command of the chat, see RegisterCommand
+type Command struct {
+	// Name without slash: lowercase letters, digits, '-' or '_'
+	Name string
+	// Arguments, shown by /help, e.g. "<text>"
+	Usage string
+	// One line description, shown by /help
+	Help    string
+	Handler CommandHandler
+}
+
+// Runs command. Errors with InvalidArgument, NotFound, AlreadyExists or PermissionDenied code are shown to the caller privately,
+// other errors fail the request
+type CommandHandler func(c *CommandContext) (CommandReply, error)
+
+// One call of command
+type CommandContext struct {
+	context.Context
+	// Caller
+	Login string
+	// Display name of the caller, login if caller has no nick
+	Nick string
+	Room string
+	// Text after name of the command, trimmed
+	Args string
+}
+
+// Result of command, both parts may be empty
+type CommandReply struct {
+	// Posted to the room on behalf of the caller
+	Message string
+	// Shown only to the caller
+	Private string
+}
+
+var commands = struct {
+	sync.RWMutex
+	byName map[string]Command
+}{byName: make(map[string]Command)}
+
+// Registers command, replaces built-in or registered command with the same name.
+// Panics on invalid name or nil handler, so mistakes are found at start.
+func RegisterCommand(c Command) {
+	if !commandNamePattern.MatchString(c.Name) || c.Handler == nil {
+		panic(fmt.Sprintf("invalid command /%s", c.Name))
+	}
+	commands.Lock()
+	defer commands.Unlock()
+	commands.byName[c.Name] = c
+}
+
+func lookupCommand(name string) (Command, bool) {
+	commands.RLock()
+	defer commands.RUnlock()
+	c, ok := commands.byName[name]
+	return c, ok
+}
+
+// Registered commands sorted by name
+func listCommands() []Command {
+	commands.RLock()
+	defer commands.RUnlock()
+	res := make([]Command, 0, len(commands.byName))
+	for _, c := range commands.byName {
+		res = append(res, c)
+	}
+	sort.Slice(res, func(a, b int) bool { return res[a].Name < res[b].Name })
+	return res
+}
+
+func init() {
+	RegisterCommand(Command{Name: "me", Usage: "<action>", Help: "Posts action, e.g. /me waves", Handler: meCommand})
+	RegisterCommand(Command{Name: "nick", Usage: "[name]", Help: "Sets display name, clears it without name", Handler: nickCommand})
+	RegisterCommand(Command{Name: "topic", Usage: "[text]", Help: "Sets topic of the room, shows it without text", Handler: topicCommand})
+	RegisterCommand(Command{Name: "help", Help: "Lists commands", Handler: helpCommand})
+	RegisterCommand(Command{Name: "who", Help: "Lists users active in the room", Handler: whoCommand})
+}
+
+// Splits "/name args" into name of the command and args, ok is false for usual messages
+func parseCommand(text string) (name, args string, ok bool) {
+	if !strings.HasPrefix(text, "/") || strings.HasPrefix(text, "//") {
+		return "", "", false
+	}
+	name = strings.TrimPrefix(text, "/")
+	if i := strings.IndexAny(name, " \t"); i >= 0 {
+		name, args = name[:i], strings.TrimSpace(name[i:])
+	}
+	return strings.ToLower(name), args, true
+}
+
+// Turns escaped "//text" into "/text"
+func unescapeMessage(text string) string {
+	if strings.HasPrefix(text, "//") {
+		return text[1:]
+	}
+	return text
+}
+
+// Runs command of the chat page, answers with private reply as plain text
+func handleCommand(w http.ResponseWriter, r *http.Request, login, room, name, args string) {
+	w.Header().Set(commandHeader, name)
+	// Replies echo user input, browser must not guess html from them
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	cmd, ok := lookupCommand(name)
+	if !ok {
+		commandsRun.WithLabelValues("unknown").Inc()
+		fmt.Fprintf(w, "Unknown command /%s, see /help", name)
+		return
+	}
+	commandsRun.WithLabelValues(name).Inc()
+
+	nick := nickOf(r.Context(), login)
+	c := &CommandContext{Context: r.Context(), Login: login, Nick: nick, Room: room, Args: args}
+	if c.Nick == "" {
+		c.Nick = login
+	}
+	reply, err := cmd.Handler(c)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied:
+			w.Write([]byte(status.Convert(err).Message()))
+		default:
+			logs.Ctx(r.Context()).Errorf("Error during command /%s \"%s\"", name, err)
+			writeError(w, r, err)
+		}
+		return
+	}
+	if reply.Message != "" {
+		m := models.ChatMessage{Time: time.Now().Format("2006-01-02 15:04:05"), Name: login, Nick: nick, Message: reply.Message, Room: room}
+		if !writeChatMessage(w, r, m) {
+			return
+		}
+	}
+	w.Write([]byte(reply.Private))
+}
+
+// Display name of the user, empty if user has none or redis failed
+func nickOf(ctx context.Context, login string) string {
+	u, found, err := getUser(ctx, login)
+	if err != nil {
+		logs.Ctx(ctx).Warn("Nick is not known: ", err)
+		return ""
+	}
+	if !found {
+		return ""
+	}
+	return u.Nick
+}
+
+func meCommand(c *CommandContext) (CommandReply, error) {
+	if c.Args == "" {
+		return CommandReply{Private: "Usage: /me <action>"}, nil
+	}
+	return CommandReply{Message: "* " + c.Nick + " " + c.Args}, nil
+}
+
+// Runs content filters of messages over arguments, returns them as filters changed them
+func filterArgs(c *CommandContext) (string, error) {
+	m := models.ChatMessage{Name: c.Login, Nick: c.Nick, Message: c.Args, Room: c.Room}
+	if err := filterMessage(c, &m, true); err != nil {
+		return "", err
+	}
+	return m.Message, nil
+}
+
+func nickCommand(c *CommandContext) (CommandReply, error) {
+	args, err := filterArgs(c)
+	if err != nil {
+		return CommandReply{}, err
+	}
+	c.Args = args
+	if utf8.RuneCountInString(c.Args) > maxNick || strings.ContainsAny(c.Args, " \t") {
+		return CommandReply{}, errs.Invalid("nick", "Nick must be up to 32 characters without spaces")
+	}
+	if err := RedisAdapter.SetNick(c, c.Login, c.Args); err != nil {
+		return CommandReply{}, err
+	}
+	if c.Args == "" {
+		return CommandReply{Private: "Nick is cleared"}, nil
+	}
+	return CommandReply{Private: "You are now known as " + c.Args}, nil
+}
+
+// Shows topic to everyone, sets it only by creator of the room or admin
+func topicCommand(c *CommandContext) (CommandReply, error) {
+	room, err := MongoAdapter.GetRoom(c, c.Room)
+	if err != nil {
+		return CommandReply{}, err
+	}
+	if c.Args == "" {
+		if room.Topic == "" {
+			return CommandReply{Private: "Room " + c.Room + " has no topic"}, nil
+		}
+		return CommandReply{Private: "Topic of " + c.Room + ": " + room.Topic}, nil
+	}
+	if c.Args, err = filterArgs(c); err != nil {
+		return CommandReply{}, err
+	}
+	if utf8.RuneCountInString(c.Args) > maxTopic {
+		return CommandReply{}, errs.Invalid("topic", "Topic must be up to 200 characters")
+	}
+	if room.CreatedBy != c.Login {
+		admin, err := isAdmin(c, c.Login)
+		if err != nil {
+			return CommandReply{}, err
+		}
+		if !admin {
+			return CommandReply{}, errs.New(errs.PermissionDenied, errs.ReasonNotAdmin, "Only creator of the room or admin can set topic")
+		}
+	}
+	if _, err := MongoAdapter.SetTopic(c, c.Room, c.Args); err != nil {
+		return CommandReply{}, err
+	}
+	return CommandReply{Message: "* " + c.Nick + " set topic: " + c.Args}, nil
+}
+
+func helpCommand(c *CommandContext) (CommandReply, error) {
+	var b strings.Builder
+	for _, cmd := range listCommands() {
+		line := "/" + cmd.Name
+		if cmd.Usage != "" {
+			line += " " + cmd.Usage
+		}
+		fmt.Fprintf(&b, "%s - %s\n", line, cmd.Help)
+	}
+	return CommandReply{Private: strings.TrimSuffix(b.String(), "\n")}, nil
+}
+
+func whoCommand(c *CommandContext) (CommandReply, error) {
+	users := activity.active(c.Room, whoWindow)
+	return CommandReply{Private: "Active in " + c.Room + ": " + strings.Join(users, ", ")}, nil
+}
+
+// Users seen in rooms: posting or polling messages of the room
+type roomActivity struct {
+	mu   sync.Mutex
+	seen map[string]map[string]time.Time
+}
+
+var activity = &roomActivity{seen: make(map[string]map[string]time.Time)}
+
+// Marks user as active in the room
+func (a *roomActivity) touch(room, login string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	users, ok := a.seen[room]
+	if !ok {
+		users = make(map[string]time.Time)
+		a.seen[room] = users
+	}
+	users[login] = time.Now()
+}
+
+// Users seen in the room within window, sorted, forgets the ones seen earlier
+func (a *roomActivity) active(room string, window time.Duration) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := make([]string, 0)
+	for login, t := range a.seen[room] {
+		if time.Since(t) > window {
+			delete(a.seen[room], login)
+			continue
+		}
+		res = append(res, login)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Users seen within window by room, rooms without such users are left out
+func (a *roomActivity) byRoom(window time.Duration) map[string][]string {
+	a.mu.Lock()
+	rooms := make([]string, 0, len(a.seen))
+	for room := range a.seen {
+		rooms = append(rooms, room)
+	}
+	a.mu.Unlock()
+
+	res := make(map[string][]string)
+	for _, room := range rooms {
+		if users := a.active(room, window); len(users) > 0 {
+			res[room] = users
+		}
+	}
+	return res
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text string
+		name string
+		args string
+		ok   bool
+	}{
+		{"hello", "", "", false},
+		{"", "", "", false},
+		{"//not a command", "", "", false},
+		{"a /nick inside", "", "", false},
+		{"/help", "help", "", true},
+		{"/HELP", "help", "", true},
+		{"/nick Bob", "nick", "Bob", true},
+		{"/topic  Release  notes ", "topic", "Release  notes", true},
+		{"/me\twaves", "me", "waves", true},
+		{"/", "", "", true},
+	}
+	for _, tt := range tests {
+		name, args, ok := parseCommand(tt.text)
+		if name != tt.name || args != tt.args || ok != tt.ok {
+			t.Errorf("parseCommand(%q) = %q, %q, %v, want %q, %q, %v", tt.text, name, args, ok, tt.name, tt.args, tt.ok)
+		}
+	}
+}
+
+func TestUnescapeMessage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"hello", "hello"},
+		{"//nick", "/nick"},
+		{"///", "//"},
+		{"/nick", "/nick"},
+	}
+	for _, tt := range tests {
+		if got := unescapeMessage(tt.text); got != tt.want {
+			t.Errorf("unescapeMessage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+// Users of redis with nicks, SetNick changes them
+type fakeNicks struct {
+	redisrpc.ReaderClient
+	redisrpc.WriterClient
+	nicks map[string]string
+}
+
+func (f *fakeNicks) Read(ctx context.Context, in *redisrpc.ReadRequest, opts ...grpc.CallOption) (*redisrpc.ReadResponse, error) {
+	return &redisrpc.ReadResponse{Result: &redisrpc.UserInfo{Login: in.Login, Nick: f.nicks[in.Login]}}, nil
+}
+
+func (f *fakeNicks) SetNick(ctx context.Context, in *redisrpc.SetNickRequest, opts ...grpc.CallOption) (*redisrpc.SetNickResponse, error) {
+	f.nicks[in.Login] = in.Nick
+	return &redisrpc.SetNickResponse{}, nil
+}
+
+func TestHandleCommand(t *testing.T) {
+	saved := RedisAdapter
+	defer func() { RedisAdapter = saved }()
+	users := &fakeNicks{nicks: map[string]string{"ann": "annie"}}
+	RedisAdapter = grpcRedisAdapter{readerClient: users, writerClient: users}
+
+	tests := []struct {
+		text string
+		want string
+		nick string
+	}{
+		{"/dance", "Unknown command /dance, see /help", "annie"},
+		{"/me", "Usage: /me <action>", "annie"},
+		{"/nick Ann Lee", "Nick must be up to 32 characters without spaces", "annie"},
+		{"/nick Ann", "You are now known as Ann", "Ann"},
+		{"/nick", "Nick is cleared", ""},
+	}
+	for _, tt := range tests {
+		name, args, _ := parseCommand(tt.text)
+		w := httptest.NewRecorder()
+		handleCommand(w, httptest.NewRequest(http.MethodPost, "/messages", nil), "ann", defaultRoom, name, args)
+
+		if got := w.Body.String(); got != tt.want {
+			t.Errorf("%q = %q, want %q", tt.text, got, tt.want)
+		}
+		if w.Header().Get("Content-Type") != "text/plain; charset=utf-8" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+			t.Errorf("%q: reply may be sniffed as html: %v", tt.text, w.Header())
+		}
+		if users.nicks["ann"] != tt.nick {
+			t.Errorf("%q: nick %q, want %q", tt.text, users.nicks["ann"], tt.nick)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Protection of page forms against cross-site requests: every post form carries token of the session
+// Token is hash of session id, so every instance of main knows it and it dies with the session
+
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+)
+
+// Name of hidden field of post forms
+const csrfField = "csrf"
+
+// Returns csrf token of the session
+func csrfToken(sess *session) string {
+	sum := sha256.Sum256([]byte("csrf:" + sess.id))
+	return hex.EncodeToString(sum[:])
+}
+
+// Tells if parsed post form carries csrf token of the session
+func validCSRF(r *http.Request, sess *session) bool {
+	return subtle.ConstantTimeCompare([]byte(r.PostForm.Get(csrfField)), []byte(csrfToken(sess))) == 1
+}
@@ -0,0 +1,250 @@
+// Message filters: every message of a user passes them before MongoAdapter.Write
+// Filter may change text (blocked words are masked) or reject the message with error, that is shown to the author
+// Messages of incoming hooks and arguments of /nick and /topic pass content filters too, hooks have their own rate limit instead of flood and rate filters
+
+package main
+
+import (
+	"chat_room_go/main/models"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Modes of blocklist filter
+const (
+	blocklistMask   = "mask"
+	blocklistReject = "reject"
+)
+
+// Window of posting rate limit, seconds
+const postRateWindow = 60
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+
+var messagesRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "chat_messages_rejected_total",
+	Help: "Number of messages rejected by filters, by filter name",
+}, []string{"filter"})
+
+// Filter of messages, see RegisterFilter
+type Filter struct {
+	Name string
+	// Filter also runs on edits of messages: content filters do, counters of posts do not
+	OnEdit bool
+	Apply  FilterFunc
+}
+
+// Checks message and may change its text. Error rejects the message, its text is shown to the author
+type FilterFunc func(ctx context.Context, m *models.ChatMessage) error
+
+var filters = struct {
+	sync.RWMutex
+	list []Filter
+}{}
+
+// Registers filter, it runs after filters registered before it, filter with the same name is replaced in place.
+// Panics on empty name or nil func, so mistakes are found at start.
+func RegisterFilter(f Filter) {
+	if f.Name == "" || f.Apply == nil {
+		panic(fmt.Sprintf("invalid filter %q", f.Name))
+	}
+	filters.Lock()
+	defer filters.Unlock()
+	for n := range filters.list {
+		if filters.list[n].Name == f.Name {
+			filters.list[n] = f
+			return
+		}
+	}
+	filters.list = append(filters.list, f)
+}
+
+// Runs filters over the message in order, stops at the first rejection
+func filterMessage(ctx context.Context, m *models.ChatMessage, edit bool) error {
+	filters.RLock()
+	list := filters.list
+	filters.RUnlock()
+	for _, f := range list {
+		if edit && !f.OnEdit {
+			continue
+		}
+		if err := f.Apply(ctx, m); err != nil {
+			messagesRejected.WithLabelValues(f.Name).Inc()
+			logs.Ctx(ctx).Infof("Message of %s is rejected by %s filter: %s", m.Name, f.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Built-in filters, limits of zero and empty lists in config turn them off
+func init() {
+	conf := config.Config.Filter
+	if conf.MaxLength > 0 {
+		RegisterFilter(Filter{Name: "length", OnEdit: true, Apply: maxLengthFilter(conf.MaxLength)})
+	}
+	if conf.MaxLines > 0 {
+		RegisterFilter(Filter{Name: "lines", OnEdit: true, Apply: maxLinesFilter(conf.MaxLines)})
+	}
+	if len(conf.Blocklist) > 0 {
+		RegisterFilter(Filter{Name: "blocklist", OnEdit: true, Apply: blocklistFilter(conf.Blocklist, conf.BlocklistMode)})
+	}
+	if len(conf.AllowedLinkHosts) > 0 {
+		RegisterFilter(Filter{Name: "links", OnEdit: true, Apply: linksFilter(conf.AllowedLinkHosts)})
+	}
+	if conf.FloodRepeats > 0 && conf.FloodWindow > 0 {
+		RegisterFilter(Filter{Name: "flood", Apply: floodFilter(int64(conf.FloodRepeats), int64(conf.FloodWindow))})
+	}
+	if conf.PostsPerMinute > 0 {
+		RegisterFilter(Filter{Name: "rate", Apply: rateFilter(int64(conf.PostsPerMinute))})
+	}
+}
+
+func rejected(message string) error {
+	return &errs.Error{Kind: errs.InvalidArgument, Reason: errs.ReasonMessageRejected, Message: message, Field: "text"}
+}
+
+func maxLengthFilter(max int) FilterFunc {
+	return func(ctx context.Context, m *models.ChatMessage) error {
+		if utf8.RuneCountInString(m.Message) > max {
+			return rejected(fmt.Sprintf("Message is longer than %d characters", max))
+		}
+		return nil
+	}
+}
+
+func maxLinesFilter(max int) FilterFunc {
+	return func(ctx context.Context, m *models.ChatMessage) error {
+		if strings.Count(m.Message, "\n")+1 > max {
+			return rejected(fmt.Sprintf("Message has more than %d lines", max))
+		}
+		return nil
+	}
+}
+
+// Blocked words are matched as whole words of any language, case is ignored
+func blocklistFilter(words []string, mode string) FilterFunc {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	if len(quoted) == 0 {
+		return func(ctx context.Context, m *models.ChatMessage) error { return nil }
+	}
+	// \b of regexp knows ascii words only, boundaries are checked by wholeWords
+	blocked := regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+	if mode != blocklistReject {
+		mode = blocklistMask
+	}
+	return func(ctx context.Context, m *models.ChatMessage) error {
+		found := wholeWords(blocked, m.Message)
+		if len(found) == 0 {
+			return nil
+		}
+		if mode == blocklistReject {
+			return rejected("Message contains blocked words")
+		}
+		var masked strings.Builder
+		last := 0
+		for _, loc := range found {
+			masked.WriteString(m.Message[last:loc[0]])
+			masked.WriteString(strings.Repeat("*", utf8.RuneCountInString(m.Message[loc[0]:loc[1]])))
+			last = loc[1]
+		}
+		masked.WriteString(m.Message[last:])
+		m.Message = masked.String()
+		return nil
+	}
+}
+
+// Returns positions of matches, that are not parts of longer words
+func wholeWords(re *regexp.Regexp, text string) [][]int {
+	var found [][]int
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		before, _ := utf8.DecodeLastRuneInString(text[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(text[loc[1]:])
+		if !wordRune(before) && !wordRune(after) {
+			found = append(found, loc)
+		}
+	}
+	return found
+}
+
+func wordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Links are allowed to the hosts and their subdomains only
+func linksFilter(hosts []string) FilterFunc {
+	return func(ctx context.Context, m *models.ChatMessage) error {
+		for _, link := range linkPattern.FindAllString(m.Message, -1) {
+			if !strings.Contains(link, "://") {
+				link = "http://" + link
+			}
+			u, err := url.Parse(link)
+			if err != nil || !allowedHost(strings.ToLower(u.Hostname()), hosts) {
+				return rejected("Links to this site are not allowed")
+			}
+		}
+		return nil
+	}
+}
+
+func allowedHost(host string, hosts []string) bool {
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// Counts the same text of the user within window, case and surrounding spaces are ignored
+func floodFilter(repeats, window int64) FilterFunc {
+	return func(ctx context.Context, m *models.ChatMessage) error {
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(m.Message))))
+		key := "flood:" + m.Name + ":" + hex.EncodeToString(sum[:8])
+		ok, wait := allowPost(ctx, key, repeats, window)
+		if !ok {
+			return errs.New(errs.ResourceExhausted, errs.ReasonFlood, fmt.Sprintf("You have already posted this message, try again in %d seconds", wait))
+		}
+		return nil
+	}
+}
+
+func rateFilter(perMinute int64) FilterFunc {
+	return func(ctx context.Context, m *models.ChatMessage) error {
+		ok, wait := allowPost(ctx, "post:"+m.Name, perMinute, postRateWindow)
+		if !ok {
+			return errs.New(errs.ResourceExhausted, errs.ReasonRateLimited, fmt.Sprintf("You are posting too fast, try again in %d seconds", wait))
+		}
+		return nil
+	}
+}
+
+// Counts post in redis, redis failure lets the post through
+func allowPost(ctx context.Context, key string, limit, window int64) (bool, int64) {
+	ok, wait, err := RedisAdapter.Allow(ctx, key, limit, window)
+	if err != nil {
+		logs.Ctx(ctx).Warn("Rate limit is not checked: ", err)
+		return true, 0
+	}
+	return ok, wait
+}
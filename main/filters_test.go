@@ -0,0 +1,165 @@
+package main
+
+import (
+	"chat_room_go/main/models"
+	mongorpc "chat_room_go/microservices/mongodb/pb"
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestBlocklistFilter(t *testing.T) {
+	words := []string{"darn", "heck", " ", "дурак", "c++"}
+	tests := []struct {
+		mode     string
+		text     string
+		want     string
+		rejected bool
+	}{
+		{blocklistMask, "hello world", "hello world", false},
+		{blocklistMask, "darn it", "**** it", false},
+		{blocklistMask, "Darn, HECK!", "****, ****!", false},
+		{blocklistMask, "darn darn", "**** ****", false},
+		{blocklistMask, "darning heckle", "darning heckle", false},
+		{blocklistMask, "darn_it", "darn_it", false},
+		{blocklistMask, "ты дурак", "ты *****", false},
+		{blocklistMask, "дураки", "дураки", false},
+		{blocklistMask, "I like c++.", "I like ***.", false},
+		{"", "darn", "****", false},
+		{blocklistReject, "hello world", "hello world", false},
+		{blocklistReject, "oh heck", "oh heck", true},
+		{blocklistReject, "heckle", "heckle", false},
+	}
+	for _, tt := range tests {
+		m := &models.ChatMessage{Message: tt.text}
+		err := blocklistFilter(words, tt.mode)(context.Background(), m)
+		if rejected := errs.Reason(err) == errs.ReasonMessageRejected; rejected != tt.rejected {
+			t.Errorf("blocklist %q %q: error %v, want rejected %v", tt.mode, tt.text, err, tt.rejected)
+		}
+		if m.Message != tt.want {
+			t.Errorf("blocklist %q %q = %q, want %q", tt.mode, tt.text, m.Message, tt.want)
+		}
+	}
+
+	// Blocklist of blank words blocks nothing
+	m := &models.ChatMessage{Message: "any text"}
+	if err := blocklistFilter([]string{" ", ""}, blocklistReject)(context.Background(), m); err != nil {
+		t.Errorf("blank blocklist rejects: %v", err)
+	}
+}
+
+func TestLinksFilter(t *testing.T) {
+	filter := linksFilter([]string{"example.com", "Go.dev"})
+	tests := []struct {
+		text     string
+		rejected bool
+	}{
+		{"no links here", false},
+		{"see https://example.com/page", false},
+		{"see http://docs.example.com", false},
+		{"see www.example.com", false},
+		{"see https://go.dev/doc and https://EXAMPLE.com", false},
+		{"see https://evil.com", true},
+		{"see https://example.com.evil.com", true},
+		{"see https://notexample.com", true},
+		{"see https://example.com@evil.com", true},
+		{"ok https://example.com, bad www.evil.org", true},
+	}
+	for _, tt := range tests {
+		err := filter(context.Background(), &models.ChatMessage{Message: tt.text})
+		if rejected := err != nil; rejected != tt.rejected {
+			t.Errorf("links %q: error %v, want rejected %v", tt.text, err, tt.rejected)
+		}
+	}
+}
+
+func TestAllowedHost(t *testing.T) {
+	hosts := []string{"example.com", "Go.dev"}
+	tests := []struct {
+		host    string
+		allowed bool
+	}{
+		{"example.com", true},
+		{"www.example.com", true},
+		{"a.b.example.com", true},
+		{"go.dev", true},
+		{"pkg.go.dev", true},
+		{"notexample.com", false},
+		{"example.com.evil.com", false},
+		{"example.org", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := allowedHost(tt.host, hosts); got != tt.allowed {
+			t.Errorf("allowedHost(%q) = %v, want %v", tt.host, got, tt.allowed)
+		}
+	}
+}
+
+// Messages written to mongodb
+type fakeMessages struct {
+	mongorpc.WriterClient
+	written []string
+}
+
+func (f *fakeMessages) Write(ctx context.Context, in *mongorpc.WriteRequest, opts ...grpc.CallOption) (*mongorpc.WriteResponse, error) {
+	f.written = append(f.written, in.Message)
+	return &mongorpc.WriteResponse{Result: &mongorpc.MessageInfo{Message: in.Message, Name: in.Name, Room: in.Room}}, nil
+}
+
+// Rate limiter of redis, counts hits by key without windows
+type fakeLimiter struct {
+	redisrpc.RateLimiterClient
+	hits map[string]int64
+}
+
+func (f *fakeLimiter) Allow(ctx context.Context, in *redisrpc.AllowRequest, opts ...grpc.CallOption) (*redisrpc.AllowResponse, error) {
+	f.hits[in.Key]++
+	return &redisrpc.AllowResponse{Allowed: f.hits[in.Key] <= in.Limit, RetryAfter: in.Window}, nil
+}
+
+func TestWriteChatMessageFilters(t *testing.T) {
+	savedMongo, savedRedis, savedFilters := MongoAdapter, RedisAdapter, filters.list
+	defer func() { MongoAdapter, RedisAdapter, filters.list = savedMongo, savedRedis, savedFilters }()
+	messages := &fakeMessages{}
+	MongoAdapter = grpcMongoAdapter{writerClient: messages}
+	RedisAdapter = grpcRedisAdapter{rateLimiterClient: &fakeLimiter{hits: make(map[string]int64)}}
+	filters.list = nil
+	RegisterFilter(Filter{Name: "blocklist", OnEdit: true, Apply: blocklistFilter([]string{"darn"}, blocklistMask)})
+	RegisterFilter(Filter{Name: "links", OnEdit: true, Apply: linksFilter([]string{"example.com"})})
+	RegisterFilter(Filter{Name: "rate", Apply: rateFilter(3)})
+
+	tests := []struct {
+		text    string
+		code    int
+		written string
+	}{
+		{"hello", http.StatusOK, "hello"},
+		{"darn it", http.StatusOK, "**** it"},
+		{"see https://example.com/docs", http.StatusOK, "see https://example.com/docs"},
+		// Rejected by links filter, rate filter after it does not count the post
+		{"see https://evil.test", http.StatusBadRequest, ""},
+		{"too fast", http.StatusTooManyRequests, ""},
+	}
+	for _, tt := range tests {
+		before := len(messages.written)
+		w := httptest.NewRecorder()
+		ok := writeChatMessage(w, httptest.NewRequest(http.MethodPost, "/messages", nil), models.ChatMessage{Name: "ann", Message: tt.text, Room: defaultRoom})
+
+		if w.Code != tt.code || ok != (tt.code == http.StatusOK) {
+			t.Errorf("%q: status %d, ok %v, want %d", tt.text, w.Code, ok, tt.code)
+		}
+		written := ""
+		if len(messages.written) > before {
+			written = messages.written[before]
+		}
+		if written != tt.written {
+			t.Errorf("%q: written %q, want %q", tt.text, written, tt.written)
+		}
+	}
+}
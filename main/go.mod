@@ -3,13 +3,22 @@ module main
 go 1.16
 
 require (
+	github.com/ClickHouse/clickhouse-go v1.4.8 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/gomodule/redigo v1.8.5 // indirect
 	github.com/google/go-cmp v0.5.6 // indirect
+	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
+	github.com/jmoiron/sqlx v1.3.4 // indirect
 	github.com/kr/pretty v0.1.0 // indirect
+	github.com/prometheus/client_golang v1.12.1
 	github.com/satori/go.uuid v1.2.0
+	go.mongodb.org/mongo-driver v1.7.3 // indirect
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.0.1
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.0.1
+	go.opentelemetry.io/otel/sdk v1.0.1
+	go.opentelemetry.io/otel/trace v1.0.1
 	go.uber.org/zap v1.19.1 // indirect
 	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
-	golang.org/x/net v0.0.0-20210428140749-89ef3d95e781 // indirect
 	google.golang.org/grpc v1.41.0
-	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 )
@@ -3,9 +3,11 @@ package main
 
 import (
 	"chat_room_go/main/models"
+	clickhouseconnector "chat_room_go/microservices/clickhouse/pb"
 	mongoconnector "chat_room_go/microservices/mongodb/pb"
 	redisconnector "chat_room_go/microservices/redis/pb"
 	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
 	"chat_room_go/utils/logs"
 	"context"
 	"crypto/tls"
@@ -23,27 +25,49 @@ import (
 // Adapters for grpc
 var MongoAdapter grpcMongoAdapter
 var RedisAdapter grpcRedisAdapter
+var ClickhouseAdapter grpcClickhouseAdapter
+
+// Init grpc to mongo, redis and clickhouse, in all-in-one mode connects to in-process services
+func initAdapters(services *inProcessServices) {
+	var mongoOpts, redisOpts, clickhouseOpts []grpc.DialOption
+	if services != nil {
+		mongoOpts = services.dialOptions(mongoService)
+		redisOpts = services.dialOptions(redisService)
+		clickhouseOpts = services.dialOptions(clickhouseService)
+	}
 
-// Init grpc to mongo
-func init() {
 	MongoAdapter = grpcMongoAdapter{}
-	MongoAdapter.dbParms = dbParms{DbName: config.Config.MongoAdapter.DbName, CollectionName: config.Config.MongoAdapter.CollectionName}
+	MongoAdapter.dbParms = dbParms{
+		DbName:                config.Config.MongoAdapter.DbName,
+		CollectionName:        config.Config.MongoAdapter.CollectionName,
+		RoomsCollectionName:   config.Config.MongoAdapter.RoomsCollectionName,
+		ReportsCollectionName: config.Config.MongoAdapter.ReportsCollectionName,
+	}
 	MongoAdapter.url = config.Config.MongoAdapter.URL
-	MongoAdapter.InitMongoAdapter()
-}
+	MongoAdapter.timeouts = config.Config.MongoAdapter.RPCTimeouts
+	MongoAdapter.breaker = newCircuitBreaker(mongoService)
+	MongoAdapter.InitMongoAdapter(mongoOpts...)
 
-// Init grpc to redis
-func init() {
 	RedisAdapter = grpcRedisAdapter{}
 	RedisAdapter.url = config.Config.RedisAdapter.URL
 	RedisAdapter.recParms = recParms{ExpirationTime: strconv.Itoa(sessionLength)}
-	RedisAdapter.initRedisAdapter()
+	RedisAdapter.timeouts = config.Config.RedisAdapter.RPCTimeouts
+	RedisAdapter.breaker = newCircuitBreaker(redisService)
+	RedisAdapter.initRedisAdapter(redisOpts...)
+
+	ClickhouseAdapter = grpcClickhouseAdapter{}
+	ClickhouseAdapter.url = config.Config.ClickhouseAdapter.URL
+	ClickhouseAdapter.timeouts = config.Config.ClickhouseAdapter.RPCTimeouts
+	ClickhouseAdapter.breaker = newCircuitBreaker(clickhouseService)
+	ClickhouseAdapter.initClickhouseAdapter(clickhouseOpts...)
 }
 
 // Db to write parameters
 type dbParms struct {
-	DbName         string
-	CollectionName string
+	DbName                string
+	CollectionName        string
+	RoomsCollectionName   string
+	ReportsCollectionName string
 }
 
 // Db to write parameters
@@ -51,14 +75,44 @@ type recParms struct {
 	ExpirationTime string
 }
 
+// Deadline of rpc if config has no value for it
+const defaultRPCTimeout = 5 * time.Second
+
+// Deadlines of rpcs in milliseconds by method name, "default" is used for methods not listed
+type rpcTimeouts map[string]int
+
+// Returns deadline for the method
+func (t rpcTimeouts) get(method string) time.Duration {
+	if ms, ok := t[method]; ok {
+		return time.Duration(ms) * time.Millisecond
+	}
+	if ms, ok := t["default"]; ok {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultRPCTimeout
+}
+
+// Context of one call: caller context (request) with deadline of the method, static metadata of adapter and request id
+func callContext(ctx context.Context, md metadata.MD, timeout time.Duration) (context.Context, context.CancelFunc) {
+	md = metadata.Join(md, metadata.Pairs(logs.RequestIDKey, logs.RequestID(ctx)))
+	return context.WithTimeout(metadata.NewOutgoingContext(ctx, md), timeout)
+}
+
 // Struct, that implements grpc methods for mongodb microservice
 type grpcMongoAdapter struct {
-	writerClient mongoconnector.WriterClient
-	readerClient mongoconnector.ReaderClient
-	ctx          context.Context
-	grpcConn     *grpc.ClientConn
-	dbParms      dbParms
-	url          string
+	writerClient    mongoconnector.WriterClient
+	readerClient    mongoconnector.ReaderClient
+	roomsClient     mongoconnector.RoomsClient
+	reportsClient   mongoconnector.ReportsClient
+	reactionsClient mongoconnector.ReactionsClient
+	md              metadata.MD
+	roomsMD         metadata.MD
+	reportsMD       metadata.MD
+	grpcConn        *grpc.ClientConn
+	dbParms         dbParms
+	timeouts        rpcTimeouts
+	breaker         *circuitBreaker
+	url             string
 }
 
 // Struct, that implements grpc methods for redis microservice
@@ -67,16 +121,40 @@ type grpcRedisAdapter struct {
 	readerClient        redisconnector.ReaderClient
 	writerSessionClient redisconnector.WriterSessionClient
 	getterSessionClient redisconnector.GetterSessionClient
-	ctx                 context.Context
+	tokensClient        redisconnector.TokensClient
+	hooksClient         redisconnector.IncomingHooksClient
+	outHooksClient      redisconnector.OutgoingHooksClient
+	adminClient         redisconnector.AdminClient
+	moderationClient    redisconnector.ModerationClient
+	rateLimiterClient   redisconnector.RateLimiterClient
+	mentionsClient      redisconnector.MentionsClient
+	readMarkersClient   redisconnector.ReadMarkersClient
+	md                  metadata.MD
 	grpcConn            *grpc.ClientConn
 	recParms            recParms
+	timeouts            rpcTimeouts
+	breaker             *circuitBreaker
 	url                 string
 }
 
+// Struct, that implements grpc methods for clickhouse microservice, logs themselves go through logs.WL
+type grpcClickhouseAdapter struct {
+	readerClient clickhouseconnector.ReaderClient
+	auditClient  clickhouseconnector.AuditClient
+	md           metadata.MD
+	auditMD      metadata.MD
+	grpcConn     *grpc.ClientConn
+	timeouts     rpcTimeouts
+	breaker      *circuitBreaker
+	url          string
+}
+
 // Writes user to redis
-func (w *grpcRedisAdapter) Write(u models.User) (int, error) {
+func (w *grpcRedisAdapter) Write(ctx context.Context, u models.User) (int, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Write"))
+	defer cancel()
 	_, err := w.writerClient.Write(
-		w.ctx,
+		ctx,
 		&redisconnector.WriteRequest{Login: u.Login, Fname: u.Fname, Lname: u.Lname, Pass: string(u.Pass), Role: u.Role, LastActive: time.Now().Format("2006-01-02 15:04:05")},
 	)
 	if err != nil {
@@ -85,31 +163,45 @@ func (w *grpcRedisAdapter) Write(u models.User) (int, error) {
 	return 0, nil
 }
 
-// Returns user from redis
-func (w *grpcRedisAdapter) Read(login string) (*models.User, error) {
+// Returns user from redis, nil if there is no such user
+func (w *grpcRedisAdapter) Read(ctx context.Context, login string) (*models.User, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Read"))
+	defer cancel()
 	toReturn, err := w.readerClient.Read(
-		w.ctx,
+		ctx,
 		&redisconnector.ReadRequest{Login: login},
 	)
+	if errs.Reason(err) == errs.ReasonUserNotFound {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	if toReturn.Result == nil {
-		return nil, nil
-	}
 	r := toReturn.Result
 	return &models.User{
-		Login: r.Login,
-		Fname: r.Fname,
-		Lname: r.Lname,
-		Pass:  []byte(r.Pass),
-		Role:  r.Role}, nil
+		Login:    r.Login,
+		Fname:    r.Fname,
+		Lname:    r.Lname,
+		Pass:     []byte(r.Pass),
+		Role:     r.Role,
+		Nick:     r.Nick,
+		Disabled: r.Disabled}, nil
+}
+
+// Sets display name of the user, empty nick clears it
+func (w *grpcRedisAdapter) SetNick(ctx context.Context, login, nick string) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("SetNick"))
+	defer cancel()
+	_, err := w.writerClient.SetNick(ctx, &redisconnector.SetNickRequest{Login: login, Nick: nick})
+	return err
 }
 
 // Writes session to redis
-func (w *grpcRedisAdapter) AddSession(sessionId, userName string) (int, error) {
+func (w *grpcRedisAdapter) AddSession(ctx context.Context, sessionId, userName string) (int, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("AddSession"))
+	defer cancel()
 	_, err := w.writerSessionClient.AddSession(
-		w.ctx,
+		ctx,
 		&redisconnector.AddSessionRequest{SessionId: sessionId, UserName: userName},
 	)
 	if err != nil {
@@ -118,12 +210,28 @@ func (w *grpcRedisAdapter) AddSession(sessionId, userName string) (int, error) {
 	return 0, nil
 }
 
-// Returns session from redis
-func (w *grpcRedisAdapter) GetSession(sessionId string) (string, error) {
+// Deletes session from redis
+func (w *grpcRedisAdapter) DeleteSession(ctx context.Context, sessionId string) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("DeleteSession"))
+	defer cancel()
+	_, err := w.writerSessionClient.DeleteSession(
+		ctx,
+		&redisconnector.DeleteSessionRequest{SessionId: sessionId},
+	)
+	return err
+}
+
+// Returns login of session from redis, empty if there is no such session
+func (w *grpcRedisAdapter) GetSession(ctx context.Context, sessionId string) (string, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("GetSession"))
+	defer cancel()
 	toReturn, err := w.getterSessionClient.GetSession(
-		w.ctx,
+		ctx,
 		&redisconnector.GetSessionRequest{SessionId: sessionId},
 	)
+	if errs.Reason(err) == errs.ReasonSessionNotFound {
+		return "", nil
+	}
 	if err != nil {
 		return "", err
 	}
@@ -131,18 +239,336 @@ func (w *grpcRedisAdapter) GetSession(sessionId string) (string, error) {
 	return toReturn.UserName, nil
 }
 
-// Initializes TLS, grpc mappings, context for redis
-func (w *grpcRedisAdapter) initRedisAdapter() {
-	creds, err := loadTLSCredentialsRedis()
+// Creates api token of the user, returns it with secret, that is shown only once
+func (w *grpcRedisAdapter) CreateToken(ctx context.Context, login, name string, scopes []string) (*redisconnector.TokenInfo, string, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("CreateToken"))
+	defer cancel()
+	toReturn, err := w.tokensClient.CreateToken(
+		ctx,
+		&redisconnector.CreateTokenRequest{Token: &redisconnector.TokenInfo{Login: login, Name: name, Scopes: scopes}},
+	)
 	if err != nil {
-		logs.Logger.Panic(err)
+		return nil, "", err
 	}
+	return toReturn.Token, toReturn.Secret, nil
+}
 
-	w.grpcConn, err = grpc.Dial(
-		w.url,
-		grpc.WithPerRPCCredentials(&tokenAuth{config.Config.RedisAdapter.TokenAuth}),
-		grpc.WithTransportCredentials(creds),
-	)
+// Returns token by secret, nil if there is no such token
+func (w *grpcRedisAdapter) CheckToken(ctx context.Context, secret string) (*redisconnector.TokenInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("CheckToken"))
+	defer cancel()
+	toReturn, err := w.tokensClient.CheckToken(ctx, &redisconnector.CheckTokenRequest{Secret: secret})
+	if errs.Reason(err) == errs.ReasonTokenNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toReturn, nil
+}
+
+// Returns tokens of the user
+func (w *grpcRedisAdapter) ListTokens(ctx context.Context, login string) ([]*redisconnector.TokenInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListTokens"))
+	defer cancel()
+	toReturn, err := w.tokensClient.ListTokens(ctx, &redisconnector.ListTokensRequest{Login: login})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Revokes token of the user, NotFound error if user has no such token
+func (w *grpcRedisAdapter) RevokeToken(ctx context.Context, login, id string) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("RevokeToken"))
+	defer cancel()
+	_, err := w.tokensClient.RevokeToken(ctx, &redisconnector.RevokeTokenRequest{Login: login, Id: id})
+	return err
+}
+
+// Creates incoming hook of the user, returns it with secret of its url, that is shown only once
+func (w *grpcRedisAdapter) CreateIncomingHook(ctx context.Context, hook *redisconnector.IncomingHookInfo, signed bool) (*redisconnector.IncomingHookInfo, string, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("CreateIncomingHook"))
+	defer cancel()
+	toReturn, err := w.hooksClient.CreateIncomingHook(ctx, &redisconnector.CreateIncomingHookRequest{Hook: hook, Signed: signed})
+	if err != nil {
+		return nil, "", err
+	}
+	return toReturn.Hook, toReturn.Secret, nil
+}
+
+// Returns hook by secret of its url, nil if there is no such hook
+func (w *grpcRedisAdapter) GetIncomingHook(ctx context.Context, secret string) (*redisconnector.IncomingHookInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("GetIncomingHook"))
+	defer cancel()
+	toReturn, err := w.hooksClient.GetIncomingHook(ctx, &redisconnector.GetIncomingHookRequest{Secret: secret})
+	if errs.Reason(err) == errs.ReasonHookNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toReturn, nil
+}
+
+// Returns incoming hooks of the user
+func (w *grpcRedisAdapter) ListIncomingHooks(ctx context.Context, login string) ([]*redisconnector.IncomingHookInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListIncomingHooks"))
+	defer cancel()
+	toReturn, err := w.hooksClient.ListIncomingHooks(ctx, &redisconnector.ListIncomingHooksRequest{Login: login})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Deletes incoming hook of the user, NotFound error if user has no such hook
+func (w *grpcRedisAdapter) DeleteIncomingHook(ctx context.Context, login, id string) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("DeleteIncomingHook"))
+	defer cancel()
+	_, err := w.hooksClient.DeleteIncomingHook(ctx, &redisconnector.DeleteIncomingHookRequest{Login: login, Id: id})
+	return err
+}
+
+// Creates outgoing hook of the user
+func (w *grpcRedisAdapter) CreateOutgoingHook(ctx context.Context, hook *redisconnector.OutgoingHookInfo) (*redisconnector.OutgoingHookInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("CreateOutgoingHook"))
+	defer cancel()
+	return w.outHooksClient.CreateOutgoingHook(ctx, &redisconnector.CreateOutgoingHookRequest{Hook: hook})
+}
+
+// Returns outgoing hooks of the user
+func (w *grpcRedisAdapter) ListOutgoingHooks(ctx context.Context, login string) ([]*redisconnector.OutgoingHookInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListOutgoingHooks"))
+	defer cancel()
+	toReturn, err := w.outHooksClient.ListOutgoingHooks(ctx, &redisconnector.ListOutgoingHooksRequest{Login: login})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Deletes outgoing hook of the user, NotFound error if user has no such hook
+func (w *grpcRedisAdapter) DeleteOutgoingHook(ctx context.Context, login, id string) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("DeleteOutgoingHook"))
+	defer cancel()
+	_, err := w.outHooksClient.DeleteOutgoingHook(ctx, &redisconnector.DeleteOutgoingHookRequest{Login: login, Id: id})
+	return err
+}
+
+// Returns deliveries to hooks of the user, that failed all attempts
+func (w *grpcRedisAdapter) ListDeadLetters(ctx context.Context, login string) ([]*redisconnector.DeadLetter, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListDeadLetters"))
+	defer cancel()
+	toReturn, err := w.outHooksClient.ListDeadLetters(ctx, &redisconnector.ListDeadLettersRequest{Login: login})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Returns users with login starting with prefix, password hashes are not there
+func (w *grpcRedisAdapter) ListUsers(ctx context.Context, prefix string) ([]*redisconnector.UserInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListUsers"))
+	defer cancel()
+	toReturn, err := w.adminClient.ListUsers(ctx, &redisconnector.ListUsersRequest{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Sets role of the user, NotFound error if there is no such user
+func (w *grpcRedisAdapter) SetRole(ctx context.Context, login, role string) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("SetRole"))
+	defer cancel()
+	_, err := w.adminClient.SetRole(ctx, &redisconnector.SetRoleRequest{Login: login, Role: role})
+	return err
+}
+
+// Disables or enables the user, disabling kills sessions of the user
+func (w *grpcRedisAdapter) SetDisabled(ctx context.Context, login string, disabled bool) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("SetDisabled"))
+	defer cancel()
+	_, err := w.adminClient.SetDisabled(ctx, &redisconnector.SetDisabledRequest{Login: login, Disabled: disabled})
+	return err
+}
+
+// Returns sessions of the user, of all users if login is empty
+func (w *grpcRedisAdapter) ListSessions(ctx context.Context, login string) ([]*redisconnector.SessionInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListSessions"))
+	defer cancel()
+	toReturn, err := w.adminClient.ListSessions(ctx, &redisconnector.ListSessionsRequest{Login: login})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Deletes all sessions of the user, returns their number
+func (w *grpcRedisAdapter) KillSessions(ctx context.Context, login string) (int, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("KillSessions"))
+	defer cancel()
+	toReturn, err := w.adminClient.KillSessions(ctx, &redisconnector.KillSessionsRequest{Login: login})
+	if err != nil {
+		return 0, err
+	}
+	return int(toReturn.Killed), nil
+}
+
+// Bans, mutes or times out the user for seconds, forever if seconds is 0, replaces the current restriction
+func (w *grpcRedisAdapter) SetRestriction(ctx context.Context, r *redisconnector.Restriction, seconds int64) (*redisconnector.Restriction, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("SetRestriction"))
+	defer cancel()
+	return w.moderationClient.SetRestriction(ctx, &redisconnector.SetRestrictionRequest{Restriction: r, Seconds: seconds})
+}
+
+// Returns restriction of the user, nil if there is none
+func (w *grpcRedisAdapter) GetRestriction(ctx context.Context, login string) (*redisconnector.Restriction, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("GetRestriction"))
+	defer cancel()
+	return w.moderationClient.GetRestriction(ctx, &redisconnector.GetRestrictionRequest{Login: login})
+}
+
+// Lifts restriction of the user
+func (w *grpcRedisAdapter) ClearRestriction(ctx context.Context, login string) error {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ClearRestriction"))
+	defer cancel()
+	_, err := w.moderationClient.ClearRestriction(ctx, &redisconnector.ClearRestrictionRequest{Login: login})
+	return err
+}
+
+// Returns all current restrictions
+func (w *grpcRedisAdapter) ListRestrictions(ctx context.Context) ([]*redisconnector.Restriction, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListRestrictions"))
+	defer cancel()
+	toReturn, err := w.moderationClient.ListRestrictions(ctx, &redisconnector.ListRestrictionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Counts hit of the key, tells if it is within limit per window (seconds), otherwise seconds to wait
+func (w *grpcRedisAdapter) Allow(ctx context.Context, key string, limit, window int64) (bool, int64, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Allow"))
+	defer cancel()
+	toReturn, err := w.rateLimiterClient.Allow(ctx, &redisconnector.AllowRequest{Key: key, Limit: limit, Window: window})
+	if err != nil {
+		return false, 0, err
+	}
+	return toReturn.Allowed, toReturn.RetryAfter, nil
+}
+
+// Adds mention to inboxes of users, returns logins of users, that exist and got it
+func (w *grpcRedisAdapter) AddMentions(ctx context.Context, logins []string, m *redisconnector.Mention) ([]string, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("AddMentions"))
+	defer cancel()
+	toReturn, err := w.mentionsClient.AddMentions(ctx, &redisconnector.AddMentionsRequest{Logins: logins, Mention: m})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Logins, nil
+}
+
+// Returns last 'number' mentions of the user from the newest one, only unread ones if unreadOnly, and number of unread ones
+func (w *grpcRedisAdapter) ListMentions(ctx context.Context, login string, unreadOnly bool, number int) ([]*redisconnector.Mention, int64, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListMentions"))
+	defer cancel()
+	toReturn, err := w.mentionsClient.ListMentions(ctx, &redisconnector.ListMentionsRequest{Login: login, UnreadOnly: unreadOnly, Number: int32(number)})
+	if err != nil {
+		return nil, 0, err
+	}
+	return toReturn.Results, toReturn.Unread, nil
+}
+
+// Returns number of unread mentions of the user
+func (w *grpcRedisAdapter) CountMentions(ctx context.Context, login string) (int64, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("CountMentions"))
+	defer cancel()
+	toReturn, err := w.mentionsClient.CountMentions(ctx, &redisconnector.CountMentionsRequest{Login: login})
+	if err != nil {
+		return 0, err
+	}
+	return toReturn.Unread, nil
+}
+
+// Marks mentions of the user read, all of them if ids are empty, returns number of unread ones left
+func (w *grpcRedisAdapter) MarkMentionsRead(ctx context.Context, login string, ids []string) (int64, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("MarkMentionsRead"))
+	defer cancel()
+	toReturn, err := w.mentionsClient.MarkMentionsRead(ctx, &redisconnector.MarkMentionsReadRequest{Login: login, Ids: ids})
+	if err != nil {
+		return 0, err
+	}
+	return toReturn.Unread, nil
+}
+
+// Deletes mentions in the deleted messages and in replies to them, returns number of deleted mentions
+func (w *grpcRedisAdapter) DeleteMentions(ctx context.Context, messageIds []string) (int64, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("DeleteMentions"))
+	defer cancel()
+	toReturn, err := w.mentionsClient.DeleteMentions(ctx, &redisconnector.DeleteMentionsRequest{MessageIds: messageIds})
+	if err != nil {
+		return 0, err
+	}
+	return toReturn.Deleted, nil
+}
+
+// Moves read marker of the user in the room to the message, marker does not go back, returns id of the marked message
+func (w *grpcRedisAdapter) MarkRead(ctx context.Context, login, room, id string) (string, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("MarkRead"))
+	defer cancel()
+	toReturn, err := w.readMarkersClient.MarkRead(ctx, &redisconnector.MarkReadRequest{Marker: &redisconnector.ReadMarker{Login: login, Room: room, MessageId: id}})
+	if err != nil {
+		return "", err
+	}
+	return toReturn.MessageId, nil
+}
+
+// Returns ids of last read messages of the user by room, rooms never read are missing
+func (w *grpcRedisAdapter) GetReadMarkers(ctx context.Context, login string) (map[string]string, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("GetReadMarkers"))
+	defer cancel()
+	toReturn, err := w.readMarkersClient.GetReadMarkers(ctx, &redisconnector.GetReadMarkersRequest{Login: login})
+	if err != nil {
+		return nil, err
+	}
+	markers := make(map[string]string, len(toReturn.Results))
+	for _, m := range toReturn.Results {
+		markers[m.Room] = m.MessageId
+	}
+	return markers, nil
+}
+
+// Returns markers of users, who have read the room up to the message or further, sorted by login
+func (w *grpcRedisAdapter) ListReaders(ctx context.Context, room, id string) ([]*redisconnector.ReadMarker, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ListReaders"))
+	defer cancel()
+	toReturn, err := w.readMarkersClient.ListReaders(ctx, &redisconnector.ListReadersRequest{Room: room, MessageId: id})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Initializes TLS (unless dial options are given), grpc mappings, metadata for redis
+func (w *grpcRedisAdapter) initRedisAdapter(opts ...grpc.DialOption) {
+	// Without explicit dial options we go to the standalone microservice with TLS and token
+	if len(opts) == 0 {
+		creds, err := loadTLSCredentialsRedis()
+		if err != nil {
+			logs.Logger.Panic(err)
+		}
+		opts = []grpc.DialOption{
+			grpc.WithPerRPCCredentials(&tokenAuth{config.Config.RedisAdapter.TokenAuth}),
+			grpc.WithTransportCredentials(creds),
+		}
+	}
+
+	var err error
+	opts = append(opts, grpc.WithChainUnaryInterceptor(tracingClientInterceptor, resilienceInterceptor(w.breaker)))
+	w.grpcConn, err = grpc.Dial(w.url, opts...)
 	if err != nil {
 		logs.Logger.Panic("cant connect to grpc")
 	}
@@ -151,69 +577,302 @@ func (w *grpcRedisAdapter) initRedisAdapter() {
 	w.readerClient = redisconnector.NewReaderClient(w.grpcConn)
 	w.getterSessionClient = redisconnector.NewGetterSessionClient(w.grpcConn)
 	w.writerSessionClient = redisconnector.NewWriterSessionClient(w.grpcConn)
-
-	w.ctx = context.Background()
-	md := metadata.Pairs(
-		"api-req-id", "123qwe",
+	w.tokensClient = redisconnector.NewTokensClient(w.grpcConn)
+	w.hooksClient = redisconnector.NewIncomingHooksClient(w.grpcConn)
+	w.outHooksClient = redisconnector.NewOutgoingHooksClient(w.grpcConn)
+	w.adminClient = redisconnector.NewAdminClient(w.grpcConn)
+	w.moderationClient = redisconnector.NewModerationClient(w.grpcConn)
+	w.rateLimiterClient = redisconnector.NewRateLimiterClient(w.grpcConn)
+	w.mentionsClient = redisconnector.NewMentionsClient(w.grpcConn)
+	w.readMarkersClient = redisconnector.NewReadMarkersClient(w.grpcConn)
+
+	// Static metadata, attached to every call
+	w.md = metadata.Pairs(
 		"expirationtime", w.recParms.ExpirationTime,
 	)
-	sHeader := metadata.Pairs("authorization", config.Config.RedisAdapter.TokenAuth)
-	grpc.SendHeader(w.ctx, sHeader)
-	w.ctx = metadata.NewOutgoingContext(w.ctx, md)
 }
 
-// Writes message to mongodb storage
-func (w *grpcMongoAdapter) Write(message, name, time string) (int, error) {
-	_, err := w.writerClient.Write(
-		w.ctx,
-		&mongoconnector.WriteRequest{Message: message, Name: name, Time: time},
+// Writes message to mongodb storage, returns stored message with id
+func (w *grpcMongoAdapter) Write(ctx context.Context, m models.ChatMessage) (*mongoconnector.MessageInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Write"))
+	defer cancel()
+	toReturn, err := w.writerClient.Write(
+		ctx,
+		&mongoconnector.WriteRequest{Message: m.Message, Name: m.Name, Time: m.Time, Room: m.Room, Bot: m.Bot, Nick: m.Nick, Parent: m.Parent},
 	)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return 0, nil
+	return toReturn.Result, nil
 }
 
-// Returns messages from mongodb storage
-func (w *grpcMongoAdapter) Read() ([]*mongoconnector.MessageInfo, error) {
+// Changes text of message of the room, only author can do it (any author if author is empty)
+func (w *grpcMongoAdapter) Edit(ctx context.Context, room, id, author, text string) (*mongoconnector.MessageInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Edit"))
+	defer cancel()
+	return w.writerClient.Edit(
+		ctx,
+		&mongoconnector.EditRequest{Id: id, Room: room, Name: author, Message: text, Time: time.Now().Format("2006-01-02 15:04:05")},
+	)
+}
+
+// Deletes message of the room, only author can do it (any author if author is empty), returns deleted message
+func (w *grpcMongoAdapter) Delete(ctx context.Context, room, id, author string) (*mongoconnector.MessageInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Delete"))
+	defer cancel()
+	toReturn, err := w.writerClient.Delete(ctx, &mongoconnector.DeleteRequest{Id: id, Room: room, Name: author})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Result, nil
+}
+
+// Returns last 'number' messages of the room older than message 'before' (if set), tells if there are older ones
+// Reactions of viewer are marked, viewer may be empty
+func (w *grpcMongoAdapter) Read(ctx context.Context, room, before string, number int, viewer string) ([]*mongoconnector.MessageInfo, bool, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Read"))
+	defer cancel()
 	toReturn, err := w.readerClient.Read(
-		w.ctx,
-		&mongoconnector.ReadRequest{Time: time.Now().Format("2006-01-02 15:04:05"), Number: 100},
+		ctx,
+		&mongoconnector.ReadRequest{Time: time.Now().Format("2006-01-02 15:04:05"), Number: int32(number), Room: room, Before: before, Viewer: viewer},
 	)
+	if err != nil {
+		return nil, false, err
+	}
+	return toReturn.Results, toReturn.HasMore, nil
+}
+
+// Returns the first message of the thread of message id and last 'number' replies older than reply 'before' (if set),
+// tells if there are older ones. Reactions of viewer are marked, viewer may be empty
+func (w *grpcMongoAdapter) Thread(ctx context.Context, room, id, before string, number int, viewer string) (*mongoconnector.MessageInfo, []*mongoconnector.MessageInfo, bool, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Thread"))
+	defer cancel()
+	toReturn, err := w.readerClient.Thread(
+		ctx,
+		&mongoconnector.ThreadRequest{Id: id, Room: room, Number: int32(number), Before: before, Viewer: viewer},
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return toReturn.Parent, toReturn.Replies, toReturn.HasMore, nil
+}
+
+// Returns message or reply of the room as the viewer sees it
+func (w *grpcMongoAdapter) Get(ctx context.Context, room, id, viewer string) (*mongoconnector.MessageInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Get"))
+	defer cancel()
+	return w.readerClient.Get(ctx, &mongoconnector.GetRequest{Id: id, Room: room, Viewer: viewer})
+}
+
+// Returns numbers of unread messages of rooms after last read messages (markers by room, empty for rooms never read),
+// messages of viewer are not counted
+func (w *grpcMongoAdapter) CountUnread(ctx context.Context, markers map[string]string, rooms []string, viewer string) (map[string]int32, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("CountUnread"))
+	defer cancel()
+	req := &mongoconnector.CountUnreadRequest{Viewer: viewer}
+	for _, room := range rooms {
+		req.Markers = append(req.Markers, &mongoconnector.RoomMarker{Room: room, MessageId: markers[room]})
+	}
+	toReturn, err := w.readerClient.CountUnread(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	unread := make(map[string]int32, len(toReturn.Results))
+	for _, r := range toReturn.Results {
+		unread[r.Room] = r.Unread
+	}
+	return unread, nil
+}
+
+// Adds reaction of the user to message of the room or takes it back, returns the message as the user sees it
+func (w *grpcMongoAdapter) ToggleReaction(ctx context.Context, room, id, login, emoji string) (*mongoconnector.MessageInfo, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("ToggleReaction"))
+	defer cancel()
+	return w.reactionsClient.ToggleReaction(ctx, &mongoconnector.ToggleReactionRequest{Id: id, Room: room, Login: login, Emoji: emoji})
+}
+
+// Creates room
+func (w *grpcMongoAdapter) CreateRoom(ctx context.Context, room *mongoconnector.RoomInfo) (*mongoconnector.RoomInfo, error) {
+	ctx, cancel := callContext(ctx, w.roomsMD, w.timeouts.get("CreateRoom"))
+	defer cancel()
+	return w.roomsClient.CreateRoom(ctx, &mongoconnector.CreateRoomRequest{Room: room})
+}
+
+// Returns room by name, NotFound error if there is no such room
+func (w *grpcMongoAdapter) GetRoom(ctx context.Context, name string) (*mongoconnector.RoomInfo, error) {
+	ctx, cancel := callContext(ctx, w.roomsMD, w.timeouts.get("GetRoom"))
+	defer cancel()
+	return w.roomsClient.GetRoom(ctx, &mongoconnector.GetRoomRequest{Name: name})
+}
+
+// Sets topic of the room, NotFound error if there is no such room
+func (w *grpcMongoAdapter) SetTopic(ctx context.Context, name, topic string) (*mongoconnector.RoomInfo, error) {
+	ctx, cancel := callContext(ctx, w.roomsMD, w.timeouts.get("SetTopic"))
+	defer cancel()
+	return w.roomsClient.SetTopic(ctx, &mongoconnector.SetTopicRequest{Name: name, Topic: topic})
+}
+
+// Returns all rooms sorted by name
+func (w *grpcMongoAdapter) ListRooms(ctx context.Context) ([]*mongoconnector.RoomInfo, error) {
+	ctx, cancel := callContext(ctx, w.roomsMD, w.timeouts.get("ListRooms"))
+	defer cancel()
+	toReturn, err := w.roomsClient.ListRooms(ctx, &mongoconnector.ListRoomsRequest{})
 	if err != nil {
 		return nil, err
 	}
 	return toReturn.Results, nil
 }
 
-// Initializes TLS, grpc mappings, context for mongodb, might be different from redis
-func (w *grpcMongoAdapter) InitMongoAdapter() {
-	creds, err := loadTLSCredentialsMongo()
+// Reports message of the room, returns report with copy of the message
+func (w *grpcMongoAdapter) CreateReport(ctx context.Context, room, id, reporter, reason string) (*mongoconnector.ReportInfo, error) {
+	ctx, cancel := callContext(ctx, w.reportsMD, w.timeouts.get("CreateReport"))
+	defer cancel()
+	return w.reportsClient.CreateReport(ctx, &mongoconnector.CreateReportRequest{Report: &mongoconnector.ReportInfo{
+		MessageId: id,
+		Room:      room,
+		Reporter:  reporter,
+		Reason:    reason,
+		Time:      time.Now().Format("2006-01-02 15:04:05"),
+	}})
+}
+
+// Returns last 'number' reports with the status (any if empty) from the newest one
+func (w *grpcMongoAdapter) ListReports(ctx context.Context, status string, number int) ([]*mongoconnector.ReportInfo, error) {
+	ctx, cancel := callContext(ctx, w.reportsMD, w.timeouts.get("ListReports"))
+	defer cancel()
+	toReturn, err := w.reportsClient.ListReports(ctx, &mongoconnector.ListReportsRequest{Status: status, Number: int32(number)})
 	if err != nil {
-		logs.Logger.Panic(err)
+		return nil, err
 	}
+	return toReturn.Results, nil
+}
 
-	w.grpcConn, err = grpc.Dial(
-		w.url,
-		grpc.WithPerRPCCredentials(&tokenAuth{config.Config.MongoAdapter.TokenAuth}),
-		grpc.WithTransportCredentials(creds),
-	)
+// Returns report by id
+func (w *grpcMongoAdapter) GetReport(ctx context.Context, id string) (*mongoconnector.ReportInfo, error) {
+	ctx, cancel := callContext(ctx, w.reportsMD, w.timeouts.get("GetReport"))
+	defer cancel()
+	return w.reportsClient.GetReport(ctx, &mongoconnector.GetReportRequest{Id: id})
+}
+
+// Resolves open report and other open reports of the same message with decision of moderator,
+// error if the report is resolved already
+func (w *grpcMongoAdapter) ResolveReport(ctx context.Context, id, decision, moderator string) (*mongoconnector.ReportInfo, error) {
+	ctx, cancel := callContext(ctx, w.reportsMD, w.timeouts.get("ResolveReport"))
+	defer cancel()
+	return w.reportsClient.ResolveReport(ctx, &mongoconnector.ResolveReportRequest{
+		Id:         id,
+		Decision:   decision,
+		ResolvedBy: moderator,
+		ResolvedAt: time.Now().Format("2006-01-02 15:04:05"),
+	})
+}
+
+// Initializes TLS (unless dial options are given), grpc mappings, metadata for mongodb, might be different from redis
+func (w *grpcMongoAdapter) InitMongoAdapter(opts ...grpc.DialOption) {
+	// Without explicit dial options we go to the standalone microservice with TLS and token
+	if len(opts) == 0 {
+		creds, err := loadTLSCredentialsMongo()
+		if err != nil {
+			logs.Logger.Panic(err)
+		}
+		opts = []grpc.DialOption{
+			grpc.WithPerRPCCredentials(&tokenAuth{config.Config.MongoAdapter.TokenAuth}),
+			grpc.WithTransportCredentials(creds),
+		}
+	}
+
+	var err error
+	opts = append(opts, grpc.WithChainUnaryInterceptor(tracingClientInterceptor, resilienceInterceptor(w.breaker)))
+	w.grpcConn, err = grpc.Dial(w.url, opts...)
 	if err != nil {
 		logs.Logger.Panic("cant connect to grpc")
 	}
 
 	w.writerClient = mongoconnector.NewWriterClient(w.grpcConn)
 	w.readerClient = mongoconnector.NewReaderClient(w.grpcConn)
+	w.roomsClient = mongoconnector.NewRoomsClient(w.grpcConn)
+	w.reportsClient = mongoconnector.NewReportsClient(w.grpcConn)
+	w.reactionsClient = mongoconnector.NewReactionsClient(w.grpcConn)
 
-	w.ctx = context.Background()
-	md := metadata.Pairs(
-		"api-req-id", "123qwe",
+	// Static metadata, attached to every call
+	w.md = metadata.Pairs(
 		"dbname", w.dbParms.DbName,
 		"collectionname", w.dbParms.CollectionName,
 	)
-	sHeader := metadata.Pairs("authorization", config.Config.MongoAdapter.TokenAuth)
-	grpc.SendHeader(w.ctx, sHeader)
-	w.ctx = metadata.NewOutgoingContext(w.ctx, md)
+	w.roomsMD = metadata.Pairs(
+		"dbname", w.dbParms.DbName,
+		"collectionname", w.dbParms.RoomsCollectionName,
+	)
+	w.reportsMD = metadata.Pairs(
+		"dbname", w.dbParms.DbName,
+		"collectionname", w.dbParms.ReportsCollectionName,
+		"messagescollectionname", w.dbParms.CollectionName,
+	)
+}
+
+// Returns last logs from the newest one, only logs containing substring if it is set
+func (w *grpcClickhouseAdapter) Recent(ctx context.Context, limit int, contains string) ([]*clickhouseconnector.LogEntry, error) {
+	ctx, cancel := callContext(ctx, w.md, w.timeouts.get("Recent"))
+	defer cancel()
+	toReturn, err := w.readerClient.Recent(ctx, &clickhouseconnector.RecentRequest{Limit: int32(limit), Contains: contains})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Appends audit event to its table, not retried, so the event is not written twice
+func (w *grpcClickhouseAdapter) Record(ctx context.Context, e *clickhouseconnector.AuditEvent) error {
+	ctx, cancel := callContext(ctx, w.auditMD, w.timeouts.get("Record"))
+	defer cancel()
+	_, err := w.auditClient.Record(ctx, &clickhouseconnector.RecordRequest{Event: e})
+	return err
+}
+
+// Returns last audit events from the newest one, empty filters match all
+func (w *grpcClickhouseAdapter) ListAudit(ctx context.Context, limit int, actor, action, target string) ([]*clickhouseconnector.AuditEvent, error) {
+	ctx, cancel := callContext(ctx, w.auditMD, w.timeouts.get("ListAudit"))
+	defer cancel()
+	toReturn, err := w.auditClient.ListAudit(ctx, &clickhouseconnector.ListAuditRequest{Limit: int32(limit), Actor: actor, Action: action, Target: target})
+	if err != nil {
+		return nil, err
+	}
+	return toReturn.Results, nil
+}
+
+// Initializes TLS (unless dial options are given), grpc mappings, metadata for clickhouse
+func (w *grpcClickhouseAdapter) initClickhouseAdapter(opts ...grpc.DialOption) {
+	// Without explicit dial options we go to the standalone microservice with TLS and token
+	if len(opts) == 0 {
+		creds, err := loadTLSCredentialsClickhouse()
+		if err != nil {
+			logs.Logger.Panic(err)
+		}
+		opts = []grpc.DialOption{
+			grpc.WithPerRPCCredentials(&tokenAuth{config.Config.ClickhouseAdapter.TokenAuth}),
+			grpc.WithTransportCredentials(creds),
+		}
+	}
+
+	var err error
+	opts = append(opts, grpc.WithChainUnaryInterceptor(tracingClientInterceptor, resilienceInterceptor(w.breaker)))
+	w.grpcConn, err = grpc.Dial(w.url, opts...)
+	if err != nil {
+		logs.Logger.Panic("cant connect to grpc")
+	}
+
+	w.readerClient = clickhouseconnector.NewReaderClient(w.grpcConn)
+	w.auditClient = clickhouseconnector.NewAuditClient(w.grpcConn)
+
+	// Static metadata, attached to every call
+	w.md = metadata.Pairs(
+		"dbname", config.Config.ClickhouseAdapter.DbName,
+		"tablename", config.Config.ClickhouseAdapter.TableName,
+	)
+	w.auditMD = metadata.Pairs(
+		"dbname", config.Config.ClickhouseAdapter.DbName,
+		"tablename", config.Config.ClickhouseAdapter.AuditTableName,
+	)
 }
 
 // **********************************************
@@ -294,3 +953,33 @@ func loadTLSCredentialsRedis() (credentials.TransportCredentials, error) {
 
 	return credentials.NewTLS(config), nil
 }
+
+// Enables TLS and adds certificates for the clickhouse client
+func loadTLSCredentialsClickhouse() (credentials.TransportCredentials, error) {
+	// Load certificate of the CA who signed server's certificate
+	pemServerCA, err := ioutil.ReadFile("../microservices/clickhouse/certs/ca-cert.pem")
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pemServerCA) {
+		return nil, fmt.Errorf("failed to add server CA's certificate")
+	}
+
+	// Load client's certificate and private key
+	clientCert, err := tls.LoadX509KeyPair("../microservices/clickhouse/certs/client-cert.pem", "../microservices/clickhouse/certs/client-key.pem")
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the credentials and return it
+	config := &tls.Config{
+		// Self signed certificate, TODO: Let`s Encrypt
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{clientCert},
+		RootCAs:            certPool,
+	}
+
+	return credentials.NewTLS(config), nil
+}
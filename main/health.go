@@ -0,0 +1,67 @@
+// Liveness and readiness endpoints, readiness aggregates grpc health of microservices
+
+package main
+
+import (
+	"chat_room_go/utils/logs"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Timeout of health check of one dependency
+const readinessTimeout = 2 * time.Second
+
+// Readiness report, returned as json
+type readiness struct {
+	Status       string            `json:"status"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// Liveness: process is up and serves http
+func healthzHandle(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+// Readiness: all microservices report SERVING
+func readyzHandle(w http.ResponseWriter, r *http.Request) {
+	// Clickhouse keeps audit, even when logs do not go there
+	deps := map[string]*grpc.ClientConn{
+		mongoService:      MongoAdapter.grpcConn,
+		redisService:      RedisAdapter.grpcConn,
+		clickhouseService: ClickhouseAdapter.grpcConn,
+	}
+
+	report := readiness{Status: "ok", Dependencies: make(map[string]string, len(deps))}
+	code := http.StatusOK
+	for name, conn := range deps {
+		st := checkHealth(r.Context(), conn)
+		report.Dependencies[name] = st.String()
+		if st != healthpb.HealthCheckResponse_SERVING {
+			report.Status = "unavailable"
+			code = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(report)
+}
+
+// Asks grpc.health.v1 of the microservice, any error means NOT_SERVING
+func checkHealth(ctx context.Context, conn *grpc.ClientConn) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		logs.Ctx(ctx).Warn("Health check failed: ", err)
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return resp.Status
+}
@@ -0,0 +1,250 @@
+// Incoming webhooks: integrations (CI and others) post to /hooks/<secret> without user account,
+// message goes to the room of the hook as bot, through the same MongoAdapter.Write as messages of users
+
+package main
+
+import (
+	"chat_room_go/main/models"
+	redisservice "chat_room_go/microservices/redis"
+	redisrpc "chat_room_go/microservices/redis/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Path of incoming hooks, secret of hook follows it
+const hooksPath = "/hooks/"
+
+// Header with HMAC-SHA256 of payload, "sha256=<hex>", required for signed hooks
+const signatureHeader = "X-Chat-Signature"
+
+// Max size of hook payload and of message text
+const (
+	maxHookPayload = 64 << 10
+	maxHookText    = 4000
+)
+
+// Payload of incoming hook
+type hookPayload struct {
+	Text string `json:"text"`
+}
+
+// Token bucket per hook: burst of messages, then ratePerMinute
+type hookLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	// Last time idle buckets were dropped
+	swept time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+var incomingLimiter = &hookLimiter{buckets: make(map[string]*bucket)}
+
+// Takes token of the hook, returns false and time to wait when hook is over its limit
+func (l *hookLimiter) allow(id string) (bool, time.Duration) {
+	rate := float64(config.Config.Webhooks.IncomingRatePerMinute) / 60
+	burst := float64(config.Config.Webhooks.IncomingBurst)
+	if rate <= 0 {
+		return true, 0
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.sweep(now, burst/rate)
+	b, ok := l.buckets[id]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[id] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Drops buckets, that are full again: hook idle for refill seconds starts with new bucket anyway
+func (l *hookLimiter) sweep(now time.Time, refill float64) {
+	if now.Sub(l.swept) < time.Minute {
+		return
+	}
+	l.swept = now
+	for id, b := range l.buckets {
+		if now.Sub(b.last).Seconds() >= refill {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// Checks "sha256=<hex>" signature of payload
+func validSignature(signingSecret string, payload []byte, header string) bool {
+	sig, err := hex.DecodeString(strings.TrimPrefix(header, "sha256="))
+	if err != nil || !strings.HasPrefix(header, "sha256=") {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write(payload)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// Handles POST to incoming hook, answers with api envelope
+func incomingHookHandle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIJSON(w, r, http.StatusMethodNotAllowed, apiResponse{Error: &apiError{Code: "MethodNotAllowed", Message: "Only POST methods allowed"}})
+		return
+	}
+	secret := strings.TrimPrefix(r.URL.Path, hooksPath)
+	if !strings.HasPrefix(secret, redisservice.HookPrefix) {
+		writeAPIError(w, r, errs.New(errs.NotFound, errs.ReasonHookNotFound, "Hook not found"))
+		return
+	}
+	hook, err := RedisAdapter.GetIncomingHook(r.Context(), secret)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	if hook == nil {
+		writeAPIError(w, r, errs.New(errs.NotFound, errs.ReasonHookNotFound, "Hook not found"))
+		return
+	}
+
+	// Signature goes first: requests with forged signatures do not spend tokens of the hook
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxHookPayload))
+	if err != nil {
+		writeAPIError(w, r, errs.Invalid("body", "Payload is too large"))
+		return
+	}
+	if hook.SigningSecret != "" && !validSignature(hook.SigningSecret, body, r.Header.Get(signatureHeader)) {
+		writeAPIError(w, r, errs.New(errs.Unauthenticated, errs.ReasonBadSignature, "Signature of payload does not match"))
+		return
+	}
+	if ok, wait := incomingLimiter.allow(hook.Id); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		writeAPIError(w, r, errs.New(errs.ResourceExhausted, errs.ReasonRateLimited, "Hook is over its rate limit"))
+		return
+	}
+	var payload hookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeAPIError(w, r, errs.Invalid("body", "Body is not valid json: "+err.Error()))
+		return
+	}
+	if payload.Text == "" || len(payload.Text) > maxHookText {
+		writeAPIError(w, r, errs.Invalid("text", "text must be 1-4000 characters"))
+		return
+	}
+
+	// Owner answers for messages of the hook: its restrictions and content filters apply to them
+	if err := checkHookOwner(r.Context(), hook.Login); err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	m := models.ChatMessage{Time: time.Now().Format("2006-01-02 15:04:05"), Name: hook.Name, Message: payload.Text, Room: hook.Room, Bot: true}
+	if err := filterMessage(r.Context(), &m, true); err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	stored, err := MongoAdapter.Write(r.Context(), m)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	countMessage()
+	notifyMentions(r.Context(), stored)
+	writeAPIJSON(w, r, http.StatusCreated, apiResponse{Data: toMessageDTO(stored)})
+}
+
+// Returns PermissionDenied error, if owner of the hook is disabled, banned or muted
+func checkHookOwner(ctx context.Context, login string) error {
+	u, found, err := getUser(ctx, login)
+	if err != nil {
+		return err
+	}
+	if !found || u.Disabled {
+		return errs.New(errs.PermissionDenied, errs.ReasonUserDisabled, "Owner of the hook is disabled")
+	}
+	return checkMute(ctx, login)
+}
+
+// Incoming hook as api returns it, url and signing secret are known only after creation
+type incomingHookDTO struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Room          string `json:"room"`
+	Signed        bool   `json:"signed"`
+	SigningSecret string `json:"signing_secret,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	URL           string `json:"url,omitempty"`
+}
+
+type createIncomingHookBody struct {
+	// Name of the bot, messages are posted under it
+	Name string `json:"name"`
+	Room string `json:"room"`
+	// Require HMAC signature of payloads
+	Signed bool `json:"signed"`
+}
+
+func toIncomingHookDTO(h *redisrpc.IncomingHookInfo) incomingHookDTO {
+	return incomingHookDTO{ID: h.Id, Name: h.Name, Room: h.Room, Signed: h.SigningSecret != "", CreatedAt: h.CreatedAt}
+}
+
+func apiListHooks(r *apiRequest) (interface{}, *apiPagination, error) {
+	hooks, err := RedisAdapter.ListIncomingHooks(r.Context(), r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]incomingHookDTO, 0, len(hooks))
+	for _, h := range hooks {
+		res = append(res, toIncomingHookDTO(h))
+	}
+	return res, nil, nil
+}
+
+func apiCreateHook(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body createIncomingHookBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if body.Name == "" || len(body.Name) > maxTokenName {
+		return nil, nil, errs.Invalid("name", "name must be 1-64 characters")
+	}
+	if body.Room == "" {
+		body.Room = defaultRoom
+	}
+	if _, err := MongoAdapter.GetRoom(r.Context(), body.Room); err != nil {
+		return nil, nil, err
+	}
+	hook, secret, err := RedisAdapter.CreateIncomingHook(r.Context(), &redisrpc.IncomingHookInfo{Login: r.session.login, Name: body.Name, Room: body.Room}, body.Signed)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := toIncomingHookDTO(hook)
+	res.URL = hooksPath + secret
+	res.SigningSecret = hook.SigningSecret
+	return res, nil, nil
+}
+
+func apiDeleteHook(r *apiRequest) (interface{}, *apiPagination, error) {
+	return nil, nil, RedisAdapter.DeleteIncomingHook(r.Context(), r.session.login, r.param("id"))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	config "chat_room_go/utils/conf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestValidSignature(t *testing.T) {
+	payload := []byte(`{"text": "build #42 passed"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	sig := hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name    string
+		secret  string
+		payload []byte
+		header  string
+		valid   bool
+	}{
+		{"valid", "secret", payload, "sha256=" + sig, true},
+		{"no prefix", "secret", payload, sig, false},
+		{"other prefix", "secret", payload, "sha1=" + sig, false},
+		{"empty header", "secret", payload, "", false},
+		{"not hex", "secret", payload, "sha256=zz", false},
+		{"other secret", "other", payload, "sha256=" + sig, false},
+		{"other payload", "secret", []byte(`{"text": "build #43 passed"}`), "sha256=" + sig, false},
+		{"truncated", "secret", payload, "sha256=" + sig[:32], false},
+	}
+	for _, tt := range tests {
+		if got := validSignature(tt.secret, tt.payload, tt.header); got != tt.valid {
+			t.Errorf("%s: validSignature = %v, want %v", tt.name, got, tt.valid)
+		}
+	}
+}
+
+func TestHookLimiterSweep(t *testing.T) {
+	config.Config.Webhooks.IncomingRatePerMinute = 60
+	config.Config.Webhooks.IncomingBurst = 2
+	l := &hookLimiter{buckets: make(map[string]*bucket)}
+	if ok, _ := l.allow("a"); !ok {
+		t.Fatal("first message of a is limited")
+	}
+	if ok, _ := l.allow("a"); !ok {
+		t.Fatal("second message of a is limited")
+	}
+	if ok, wait := l.allow("a"); ok || wait <= 0 {
+		t.Fatalf("third message of a: allowed %v, wait %v", ok, wait)
+	}
+
+	// Bucket of a is full again after 2 seconds, sweep drops it
+	l.buckets["a"].last = time.Now().Add(-time.Minute)
+	l.swept = time.Time{}
+	if ok, _ := l.allow("b"); !ok {
+		t.Fatal("first message of b is limited")
+	}
+	if _, ok := l.buckets["a"]; ok {
+		t.Error("idle bucket of a is not dropped")
+	}
+	if _, ok := l.buckets["b"]; !ok {
+		t.Error("bucket of b is dropped")
+	}
+}
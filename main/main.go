@@ -4,17 +4,26 @@ package main
 
 import (
 	"chat_room_go/main/models"
+	mmw "chat_room_go/microservices"
 	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
 	"chat_room_go/utils/logs"
+	"chat_room_go/utils/tracing"
+	"context"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"html/template"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
-	mongorpc "chat_room_go/microservices/mongodb/pb"
-
 	uuid "github.com/satori/go.uuid"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var tpl *template.Template
@@ -25,20 +34,38 @@ var sessionLength int = config.Config.SessionExpirationTime
 // Number of messages that will be downloaded from server
 var numChatMessages int = config.Config.NumChatMessages
 
+// Room of the chat, when request does not choose one
+var defaultRoom string = config.Config.MongoAdapter.DefaultRoom
+
 func init() {
 	tpl = template.Must(template.ParseGlob("./views/*.gohtml"))
 }
 
 func main() {
-	//defer Cleanup()
+	allInOne := parseArgs(os.Args[1:])
+	shutdownTracing, err := tracing.Init("chat-main")
+	if err != nil {
+		logs.Logger.Fatal("Error during tracing initialization: ", err)
+	}
+
+	var services *inProcessServices
+	if allInOne {
+		services = startInProcessServices()
+	}
+	initAdapters(services)
+	startAudit()
+
 	// Mux for logs and panic recovery
 	techMux := http.NewServeMux()
-	techHandler := panicMiddleware(accessLogMiddleware(techMux))
+	techHandler := requestIDMiddleware(panicMiddleware(accessLogMiddleware(techMux)))
 
 	// Mux for authentification required
 	authMux := http.NewServeMux()
 	authMux.HandleFunc("/main", mainHandle)
 	authMux.HandleFunc("/messages", getMessagesHandle)
+	authMux.HandleFunc("/settings/tokens", tokensHandle)
+	authMux.HandleFunc(adminPath, adminHandle)
+	authMux.HandleFunc(adminPath+"/", adminHandle)
 	siteAuthHandler := authMiddleware(authMux)
 
 	techMux.HandleFunc("/login", loginHandle)
@@ -46,13 +73,54 @@ func main() {
 	techMux.Handle("/main", siteAuthHandler)
 	techMux.HandleFunc("/signup", signupHandle)
 	techMux.Handle("/messages", siteAuthHandler)
+	techMux.Handle("/settings/tokens", siteAuthHandler)
+	techMux.Handle(adminPath, siteAuthHandler)
+	techMux.Handle(adminPath+"/", siteAuthHandler)
 	techMux.Handle("/", http.RedirectHandler("/main", http.StatusSeeOther))
 	techMux.Handle("/favicon.ico", http.NotFoundHandler())
+	techMux.HandleFunc("/healthz", healthzHandle)
+	techMux.HandleFunc("/readyz", readyzHandle)
+	techMux.Handle(apiPrefix+"/", &apiRouter{routes: apiRoutes})
+	techMux.HandleFunc(apiPrefix+"/openapi.json", openAPIHandle)
+	techMux.HandleFunc(hooksPath, incomingHookHandle)
+
+	server := &http.Server{Addr: config.Config.ChatServeURL, Handler: techHandler}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logs.Logger.Fatal("Error during listen and serve: ", err)
+		}
+	}()
+	metricsServer := mmw.ServeMetrics(config.Config.MetricsURL)
+	logs.Logger.Infof("Started server")
 
-	http.ListenAndServe(config.Config.ChatServeURL, techHandler)
+	sig := mmw.WaitForSignal()
+	logs.Logger.Infof("Received %s, shutting down", sig)
+	ctx, cancel := context.WithTimeout(context.Background(), config.Config.ShutdownDuration())
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logs.Logger.Error("Error during http server shutdown: ", err)
+	}
+	metricsServer.Shutdown(ctx)
+	stopAudit(ctx)
+	Cleanup(ctx, services)
+	shutdownTracing(ctx)
+}
 
-	logs.Logger.Infof("Started server")
-	logs.Logger.Sync()
+// Parses command line: "chatroom [serve [--all-in-one]]", returns if all-in-one mode requested
+func parseArgs(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if args[0] != "serve" {
+		fmt.Fprintf(os.Stderr, "unknown command %q\nusage: chatroom serve [--all-in-one]\n", args[0])
+		os.Exit(2)
+	}
+
+	serveCmd := flag.NewFlagSet("serve", flag.ExitOnError)
+	allInOne := serveCmd.Bool("all-in-one", false, "host mongodb, redis and clickhouse microservices in-process")
+	serveCmd.Parse(args[1:])
+
+	return *allInOne
 }
 
 // Recover from panic logic here
@@ -60,7 +128,7 @@ func panicMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				logs.Logger.Errorf("Recovered from panic %s", err)
+				logs.Ctx(r.Context()).Errorf("Recovered from panic %s", err)
 				http.Error(w, "Internal server error", http.StatusInternalServerError)
 			}
 		}()
@@ -68,25 +136,52 @@ func panicMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// Prints logs, response and processing time
+// Prints logs, response and processing time, records metrics per route
 func accessLogMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logs.Logger.Info(r)
+		logs.Ctx(r.Context()).Info(r)
 		start := time.Now()
-		next.ServeHTTP(w, r)
-		logs.Logger.Infof("[%s] Time elapsed: %s\n", r, time.Since(start))
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		observeHTTP(routeOf(next, r), r, rec.status, start)
+		logs.Ctx(r.Context()).Infof("[%s] Time elapsed: %s\n", r, time.Since(start))
 	})
 }
 
 // Checks authentification via cookie
 func authMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		isLoggedIn := isLoggedIn(r)
-		if !isLoggedIn {
+		sess, err := getSession(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if sess == nil {
+			destroySessionCookie(w, r)
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		if !sess.allows(methodScope(r)) {
+			http.Error(w, status.Convert(errScope(methodScope(r))).Message(), http.StatusForbidden)
+			return
+		}
+		// Banned user loses the session, as if it expired
+		if !sess.readOnly && checkBan(r.Context(), sess.login) != nil {
+			if !sess.apiToken {
+				RedisAdapter.DeleteSession(r.Context(), sess.id)
+			}
+			recentSessions.forget(sess.id)
 			destroySessionCookie(w, r)
 			http.Redirect(w, r, "/login", http.StatusSeeOther)
 			return
 		}
+		r = r.WithContext(withSession(r.Context(), sess))
+		// Redis is unavailable, but session was confirmed recently, so user can read
+		if sess.readOnly {
+			w.Header().Set(readOnlyHeader, "true")
+			next.ServeHTTP(w, r.WithContext(withReadOnly(r.Context())))
+			return
+		}
 		// TODO: REMOVE
 		updateSession(w, r)
 		//http.Redirect(w, r, "/main", http.StatusSeeOther)
@@ -94,11 +189,41 @@ func authMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// Closes grpc connections
-func Cleanup() {
-	defer logs.WL.GrpcConn.Close()
-	defer MongoAdapter.grpcConn.Close()
-	defer RedisAdapter.grpcConn.Close()
+// Answers with http status, that corresponds to grpc code of microservice error
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	code := errs.HTTPStatus(err)
+	log := logs.Ctx(r.Context())
+	switch {
+	case code == errs.StatusClientClosedRequest:
+		// Client is gone, status goes to access log only
+		log.Debugf("Request is canceled (%s): %s", errs.Reason(err), err)
+		w.WriteHeader(code)
+		return
+	case code < http.StatusInternalServerError:
+		log.Infof("Microservice error (%s): %s", errs.Reason(err), err)
+	default:
+		log.Errorf("Microservice error (%s): %s", errs.Reason(err), err)
+	}
+	if code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout {
+		writeUnavailable(w)
+		return
+	}
+	http.Error(w, http.StatusText(code), code)
+}
+
+// Closes grpc connections, stops in-process services (if any) and flushes logs
+func Cleanup(ctx context.Context, services *inProcessServices) {
+	MongoAdapter.grpcConn.Close()
+	RedisAdapter.grpcConn.Close()
+	ClickhouseAdapter.grpcConn.Close()
+	if services != nil {
+		services.stop(ctx)
+	}
+
+	logs.Logger.Sync()
+	if logs.WL.GrpcConn != nil {
+		logs.WL.GrpcConn.Close()
+	}
 }
 
 // Handles signup page TODO: rework front
@@ -108,26 +233,31 @@ func signupHandle(w http.ResponseWriter, r *http.Request) {
 		// get form values
 		u, err := getUserFromForm(r)
 		if err != nil {
-			logs.Logger.Error("Error while acquiring user from form: ", err)
+			logs.Ctx(r.Context()).Error("Error while acquiring user from form: ", err)
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
 		// username taken?
-		res, err := RedisAdapter.Read(u.Login)
+		res, err := RedisAdapter.Read(r.Context(), u.Login)
 		if err != nil {
-			logs.Logger.Panic(err)
+			writeError(w, r, err)
+			return
 		}
 		if res != nil {
+			audit(r.Context(), u.Login, auditSignup, u.Login, "", errs.New(errs.AlreadyExists, errs.ReasonUserExists, "Username already taken"))
 			http.Error(w, "Username already taken", http.StatusForbidden)
 			return
 		}
-		err = setSessionCookie(w, r, u.Login)
+		_, err = RedisAdapter.Write(r.Context(), *u)
+		audit(r.Context(), u.Login, auditSignup, u.Login, "role="+u.Role, err)
 		if err != nil {
-			logs.Logger.Panic("Error during session creation", err)
+			writeError(w, r, err)
+			return
 		}
-		_, err = RedisAdapter.Write(*u)
+		err = setSessionCookie(w, r, u.Login)
 		if err != nil {
-			logs.Logger.Panic(err)
+			writeError(w, r, err)
+			return
 		}
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
@@ -137,7 +267,7 @@ func signupHandle(w http.ResponseWriter, r *http.Request) {
 }
 
 func getUserFromForm(r *http.Request) (*models.User, error) {
-	bs, err := bcrypt.GenerateFromPassword([]byte(r.FormValue("password")), bcrypt.MinCost)
+	bs, err := hashPassword(r.FormValue("password"))
 	if err != nil {
 		return nil, err
 	}
@@ -149,50 +279,78 @@ func getUserFromForm(r *http.Request) (*models.User, error) {
 		Role:  r.FormValue("role")}, nil
 }
 
+// Returns bcrypt hash of password, as stored in redis
+func hashPassword(password string) ([]byte, error) {
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+}
+
 // Returns messages to front
 func getMessagesHandle(w http.ResponseWriter, r *http.Request) {
-	logs.Logger.Info(r)
+	logs.Ctx(r.Context()).Info(r)
 	if r.Method != http.MethodGet {
 		http.Error(w, "Only GET methods allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	dbMessages, err := MongoAdapter.Read()
-	if err != nil {
-		logs.Logger.Error(err)
+	// We give to front only last 'numChatMessages' messages of the room
+	room := roomOf(r)
+	viewer := ""
+	if sess := sessionOf(r.Context()); sess != nil {
+		activity.touch(room, sess.login)
+		viewer = sess.login
 	}
-	// We give to front only last 'numChatMessages' messages
-	numOfAllMess := len(dbMessages)
-	var lastMessages = make([]*mongorpc.MessageInfo, 0, numChatMessages)
-	if numOfAllMess < numChatMessages {
-		lastMessages = dbMessages
-	} else {
-		lastMessages = dbMessages[numOfAllMess-numChatMessages : numOfAllMess]
+	lastMessages, _, err := MongoAdapter.Read(r.Context(), room, "", numChatMessages, viewer)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
 	// Return messages to front as json
 	outputJSON, err := json.Marshal(lastMessages)
 	if err != nil {
-		logs.Logger.Error(err)
+		logs.Ctx(r.Context()).Error(err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if viewer != "" {
+		w.Header().Set(mentionsHeader, strconv.FormatInt(unreadMentions(r.Context(), viewer), 10))
+	}
 
 	w.Write(outputJSON)
 }
 
+// Returns room chosen by "room" form or query value, default room if none
+func roomOf(r *http.Request) string {
+	if room := r.FormValue("room"); room != "" {
+		return room
+	}
+	return defaultRoom
+}
+
 // Handles login page
 func loginHandle(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodPost {
 		// Check login and password
-		user, ok := checkUserInfo(r)
+		user, ok, err := checkUserInfo(r)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
 		if !ok {
-			http.Error(w, "Username and/or password do not match", http.StatusForbidden)
+			audit(r.Context(), r.FormValue("username"), auditLogin, r.FormValue("username"), "", errWrongPassword)
+			http.Error(w, errWrongPassword.Message, http.StatusForbidden)
+			return
+		}
+		if err := checkBan(r.Context(), user.Login); err != nil {
+			audit(r.Context(), user.Login, auditLogin, user.Login, "", err)
+			http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
 			return
 		}
 		// Set cookie
-		err := setSessionCookie(w, r, user.Login)
+		err = setSessionCookie(w, r, user.Login)
+		audit(r.Context(), user.Login, auditLogin, user.Login, "", err)
 		if err != nil {
-			logs.Logger.Panic("Error during session creation", err)
+			writeError(w, r, err)
+			return
 		}
 		// Redirect to main after logging in
 		http.Redirect(w, r, "/main", http.StatusSeeOther)
@@ -204,26 +362,42 @@ func loginHandle(w http.ResponseWriter, r *http.Request) {
 	}
 	err := tpl.ExecuteTemplate(w, "login.gohtml", "Sam")
 	if err != nil {
-		logs.Logger.Panic(err)
+		logs.Ctx(r.Context()).Panic(err)
 	}
 }
 
-// Checks if user provided correct login and password, returns corresponding user
-func checkUserInfo(r *http.Request) (*models.User, bool) {
-	un := r.FormValue("username")
-	pswrd := r.FormValue("password")
+// Checks if user provided correct login and password, returns corresponding user, error if redis failed
+func checkUserInfo(r *http.Request) (*models.User, bool, error) {
+	return checkPassword(r.Context(), r.FormValue("username"), r.FormValue("password"))
+}
+
+// Answer to wrong login or password, it does not tell which one is wrong
+var errWrongPassword = errs.New(errs.Unauthenticated, errs.ReasonUnauthenticated, "Username and/or password do not match")
+
+// Checks if password matches the stored one, returns corresponding user, error if redis failed
+func checkPassword(ctx context.Context, un, pswrd string) (*models.User, bool, error) {
 	// is there a username?
-	u, isFound := getUser(un)
-	if !isFound {
-		return nil, false
+	u, isFound, err := getUser(ctx, un)
+	if err != nil {
+		return nil, false, err
+	}
+	if !isFound || u.Disabled {
+		return nil, false, nil
 	}
 	// does the entered password match the stored password?
-	err := bcrypt.CompareHashAndPassword([]byte(u.Pass), []byte(pswrd))
+	err = bcrypt.CompareHashAndPassword([]byte(u.Pass), []byte(pswrd))
 	if err != nil {
-		return nil, false
+		return nil, false, nil
 	}
 
-	return u, true
+	return u, true, nil
+}
+
+// Data of main page
+type mainPage struct {
+	Admin bool
+	// Room shown first
+	Room string
 }
 
 // Handles main page
@@ -232,10 +406,14 @@ func mainHandle(w http.ResponseWriter, r *http.Request) {
 		lout := r.FormValue("logout")
 		if lout == "true" {
 			// delete the session
-			isFound := isLoggedIn(r)
-			if !isFound {
+			sess, err := getSession(r)
+			if err == nil && sess == nil {
 				// We should not get here without session (middleware should handle), then panic (middleware will handle)
-				logs.Logger.Panic("Session not found")
+				logs.Ctx(r.Context()).Panic("Session not found")
+			}
+			if sess != nil {
+				recentSessions.forget(sess.id)
+				audit(r.Context(), sess.login, auditLogout, sess.login, "", nil)
 			}
 			destroySessionCookie(w, r)
 			updateSession(w, r)
@@ -245,110 +423,213 @@ func mainHandle(w http.ResponseWriter, r *http.Request) {
 	} else if r.Method == http.MethodPost {
 		err := r.ParseForm()
 		if err != nil {
-			logs.Logger.Panic(err)
+			http.Error(w, "Malformed form", http.StatusBadRequest)
+			return
 		}
 
+		if isReadOnly(r.Context()) {
+			writeReadOnly(w)
+			return
+		}
 		sMess := r.PostForm.Get("usermsg")
-		sess, isFound := getSession(w, r)
-		if !isFound {
-			logs.Logger.Panic("User not found")
+		sess, err := getSession(r)
+		if err != nil && !isUnavailable(err) {
+			writeError(w, r, err)
+			return
+		}
+		if err != nil || (sess != nil && sess.readOnly) {
+			writeReadOnly(w)
+			return
+		}
+		if sess == nil {
+			logs.Ctx(r.Context()).Panic("User not found")
 		}
 		if sMess != "" {
-			m := models.ChatMessage{Time: time.Now().Format("2006-01-02 15:04:05"), Name: sess.login, Message: sMess}
-			_, err := MongoAdapter.Write(m.Message, m.Name, m.Time)
-			if err != nil {
-				logs.Logger.Info(err)
+			if err := checkMute(r.Context(), sess.login); status.Code(err) == codes.PermissionDenied {
+				http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+				return
+			} else if err != nil {
+				writeError(w, r, err)
+				return
+			}
+			room := roomOf(r)
+			activity.touch(room, sess.login)
+			if name, args, ok := parseCommand(sMess); ok {
+				handleCommand(w, r, sess.login, room, name, args)
+				return
+			}
+			m := models.ChatMessage{Time: time.Now().Format("2006-01-02 15:04:05"), Name: sess.login, Nick: nickOf(r.Context(), sess.login), Message: unescapeMessage(sMess), Room: room, Parent: r.PostForm.Get("parent")}
+			if !writeChatMessage(w, r, m) {
+				return
 			}
 		}
 	}
-	err := tpl.ExecuteTemplate(w, "index.gohtml", nil)
+	// Admins see link to admin pages, failed check only hides it
+	page := mainPage{Room: defaultRoom}
+	if sess := sessionOf(r.Context()); sess != nil && !sess.apiToken && r.Method == http.MethodGet {
+		page.Admin, _ = isAdmin(r.Context(), sess.login)
+	}
+	err := tpl.ExecuteTemplate(w, "index.gohtml", page)
 	if err != nil {
-		logs.Logger.Error(err)
+		logs.Ctx(r.Context()).Error(err)
 		http.Error(w, "Error during processing template", http.StatusInternalServerError)
-		logs.Logger.Panic(err)
+		logs.Ctx(r.Context()).Panic(err)
+	}
+}
+
+// Writes message of the chat page to its room after filters, false if response is already written with error
+func writeChatMessage(w http.ResponseWriter, r *http.Request, m models.ChatMessage) bool {
+	if m.Room != defaultRoom {
+		if _, err := MongoAdapter.GetRoom(r.Context(), m.Room); err != nil {
+			writeError(w, r, err)
+			return false
+		}
+	}
+	// Rejection is shown to the author as is
+	if err := filterMessage(r.Context(), &m, false); err != nil {
+		http.Error(w, status.Convert(err).Message(), errs.HTTPStatus(err))
+		return false
+	}
+	stored, err := MongoAdapter.Write(r.Context(), m)
+	if isUnavailable(err) {
+		logs.Ctx(r.Context()).Error(err)
+		writeReadOnly(w)
+		return false
+	} else if err != nil {
+		writeError(w, r, err)
+		return false
 	}
+	countMessage()
+	notifyMentions(r.Context(), stored)
+	return true
 }
 
-// Gets user info from cookie
-func getUser(login string) (*models.User, bool) {
+// Gets user info from cookie, error if redis failed
+func getUser(ctx context.Context, login string) (*models.User, bool, error) {
 	// if the user exists already, get user
-	res, err := RedisAdapter.Read(login)
+	res, err := RedisAdapter.Read(ctx, login)
 	if err != nil {
-		logs.Logger.Warn(err)
-		return nil, false
+		return nil, false, err
 	}
 	if res == nil {
-		return nil, false
+		return nil, false, nil
 	}
 
-	return res, true
+	return res, true, nil
 }
 
 type session struct {
-	cookie string
-	login  string
+	// Id of the session: value of cookie "session" or bearer token
+	id    string
+	login string
+	// Redis is unavailable, session was taken from recently confirmed ones
+	readOnly bool
+	// Session comes from personal api token, such session is limited by scopes
+	apiToken bool
+	scopes   []string
 }
 
-// Gets username from cookie "session" as a *string
-func getSession(w http.ResponseWriter, r *http.Request) (*session, bool) {
+// Returns session id from "Authorization: Bearer" header or from cookie "session", empty id is no session
+func sessionID(r *http.Request) (string, bool) {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		id := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+		return id, id != ""
+	}
 	c, err := r.Cookie("session")
-	if err == http.ErrNoCookie {
-		return nil, false
-	} else if err != nil {
-		logs.Logger.Panic("Error while acquiring cookie")
+	if err != nil || c.Value == "" {
+		return "", false
 	}
+	return c.Value, true
+}
 
-	record, err := RedisAdapter.GetSession(c.Value)
-	if err != nil {
-		logs.Logger.Panic("Error while retrieving value from cache, getUsernameFromSession: ", err)
+// Gets session by bearer token or cookie "session", nil if there is no session
+// When redis is unavailable falls back to recently confirmed sessions in read-only mode, error if session is not there
+func getSession(r *http.Request) (*session, error) {
+	id, ok := sessionID(r)
+	if !ok {
+		return nil, nil
 	}
-	if record == "" {
-		return nil, false
+	if isAPIToken(id) {
+		return getTokenSession(r, id)
 	}
 
-	return &session{cookie: c.Value, login: record}, true
-}
-
-// Check if user logged in, when no need of certain value of cookie
-func isLoggedIn(r *http.Request) bool {
-	c, err := r.Cookie("session")
-	if err == http.ErrNoCookie {
-		return false
+	record, err := RedisAdapter.GetSession(r.Context(), id)
+	if isUnavailable(err) {
+		cached, ok := recentSessions.lookup(id)
+		if !ok {
+			return nil, err
+		}
+		logs.Ctx(r.Context()).Warn("Redis is unavailable, serving session in read-only mode: ", err)
+		return &session{id: id, login: cached.login, readOnly: true}, nil
 	} else if err != nil {
-		logs.Logger.Panic("Error while acquiring cookie")
-	}
-	record, err := RedisAdapter.GetSession(c.Value)
-	if err != nil {
-		logs.Logger.Panic("Error while retrieving value from cache, isLoggedIn: ", err)
+		return nil, err
 	}
 	if record == "" {
-		return false
+		return nil, nil
 	}
+	recentSessions.remember(id, record)
 
-	return true
+	return &session{id: id, login: record}, nil
+}
+
+type sessionKey struct{}
+
+// Keeps session, checked by middleware, for handlers
+func withSession(ctx context.Context, sess *session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, sess)
+}
+
+// Returns session checked by middleware, nil outside of it
+func sessionOf(ctx context.Context) *session {
+	sess, _ := ctx.Value(sessionKey{}).(*session)
+	return sess
+}
+
+// Check if user logged in, when no need of certain value of cookie
+func isLoggedIn(r *http.Request) (bool, error) {
+	sess, err := getSession(r)
+	return sess != nil, err
 }
 
 // Check if user logged in
 func setSessionCookie(w http.ResponseWriter, r *http.Request, login string) error {
-	sID := uuid.NewV4()
+	sID, err := newSession(r.Context(), login)
+	if err != nil {
+		return err
+	}
+	// Scripts of pages do not need the cookie, cross-site posts do not carry it
 	c := &http.Cookie{
-		Name:   "session",
-		Value:  sID.String(),
-		MaxAge: int(sessionLength),
+		Name:     "session",
+		Value:    sID,
+		MaxAge:   int(sessionLength),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
 	}
 	http.SetCookie(w, c)
-	RedisAdapter.AddSession(sID.String(), login)
 
 	return nil
 }
 
+// Writes new session of the user to redis, returns its id (cookie value or bearer token)
+func newSession(ctx context.Context, login string) (string, error) {
+	sID := uuid.NewV4().String()
+	_, err := RedisAdapter.AddSession(ctx, sID, login)
+	if err != nil {
+		return "", err
+	}
+	return sID, nil
+}
+
 // Updates cookie, and memcache record lifetime, if session is not in cache, then delete it
 func updateSession(w http.ResponseWriter, r *http.Request) error {
 	c, err := r.Cookie("session")
 	if err != nil {
 		return err
 	}
-	isFound := isLoggedIn(r)
+	isFound, err := isLoggedIn(r)
+	if err != nil {
+		return err
+	}
 	if !isFound {
 		destroySessionCookie(w, r)
 	}
@@ -361,9 +642,11 @@ func updateSession(w http.ResponseWriter, r *http.Request) error {
 // TODO: new grpc method
 func destroySessionCookie(w http.ResponseWriter, r *http.Request) {
 	c := &http.Cookie{
-		Name:   "session",
-		Value:  "",
-		MaxAge: -1,
+		Name:     "session",
+		Value:    "",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
 	}
 	http.SetCookie(w, c)
 }
@@ -0,0 +1,149 @@
+// Mentions: "@login" in a posted message goes to inbox of the user, redis microservice keeps inbox with read state
+// Chat page shows number of unread mentions, api lists them and marks them read
+
+package main
+
+import (
+	mongorpc "chat_room_go/microservices/mongodb/pb"
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/logs"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Max number of users notified by one message, the rest of mentions are ignored
+const maxMentions = 10
+
+// Max length in runes of the message text kept with mention
+const maxMentionText = 200
+
+// Max number of mentions returned by api
+const maxMentionsNumber = 200
+
+// Header of chat page polls with number of unread mentions of the user
+const mentionsHeader = "X-Chat-Mentions"
+
+// "@login" at the start of text or after a character, that is not part of a word
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.-]+)`)
+
+// Mention as api returns it
+type mentionDTO struct {
+	ID string `json:"id"`
+	// Message with the mention
+	MessageID string `json:"message_id"`
+	Room      string `json:"room"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	Time      string `json:"time"`
+	// Id of the thread, if the message is a reply
+	Parent string `json:"parent,omitempty"`
+	Read   bool   `json:"read"`
+}
+
+type mentionsCountDTO struct {
+	Unread int64 `json:"unread"`
+}
+
+type markMentionsBody struct {
+	// Ids of mentions, all mentions are marked read if empty
+	Ids []string `json:"ids"`
+}
+
+// Returns mentioned logins of the text in order of appearance, without repeats and the author
+func parseMentions(text, author string) []string {
+	var logins []string
+	seen := map[string]bool{author: true}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Dot or dash at the end is punctuation of the sentence: "ask @bob."
+		login := strings.TrimRight(match[1], ".-")
+		if login == "" || seen[login] {
+			continue
+		}
+		seen[login] = true
+		logins = append(logins, login)
+		if len(logins) == maxMentions {
+			break
+		}
+	}
+	return logins
+}
+
+// Puts the stored message to inboxes of mentioned users, unknown logins are skipped by redis
+// Failure does not fail the posting, it goes to logs
+func notifyMentions(ctx context.Context, m *mongorpc.MessageInfo) {
+	logins := parseMentions(m.GetMessage(), m.GetName())
+	if len(logins) == 0 {
+		return
+	}
+	text := m.Message
+	if utf8.RuneCountInString(text) > maxMentionText {
+		text = string([]rune(text)[:maxMentionText]) + "…"
+	}
+	mention := &redisrpc.Mention{MessageId: m.Id, Room: m.Room, Author: m.Name, Text: text, Parent: m.Parent}
+	if _, err := RedisAdapter.AddMentions(ctx, logins, mention); err != nil {
+		logs.Ctx(ctx).Errorf("Mentions are not stored \"%s\": %v", err, logins)
+	}
+}
+
+// Removes mentions of the deleted message (and of its replies) from inboxes
+// Failure does not fail the deletion, it goes to logs
+func dropMentions(ctx context.Context, id string) {
+	if _, err := RedisAdapter.DeleteMentions(ctx, []string{id}); err != nil {
+		logs.Ctx(ctx).Errorf("Mentions of deleted message %s are not removed \"%s\"", id, err)
+	}
+}
+
+// Returns number of unread mentions of the user for chat page, 0 if redis failed
+func unreadMentions(ctx context.Context, login string) int64 {
+	unread, err := RedisAdapter.CountMentions(ctx, login)
+	if err != nil {
+		logs.Ctx(ctx).Error(err)
+		return 0
+	}
+	return unread
+}
+
+func apiListMentions(r *apiRequest) (interface{}, *apiPagination, error) {
+	limit, err := parseLimit(r, defaultAPILimit, maxMentionsNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+	mentions, _, err := RedisAdapter.ListMentions(r.Context(), r.session.login, unreadOnly, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]mentionDTO, 0, len(mentions))
+	for _, m := range mentions {
+		res = append(res, toMentionDTO(m))
+	}
+	return res, nil, nil
+}
+
+func apiCountMentions(r *apiRequest) (interface{}, *apiPagination, error) {
+	unread, err := RedisAdapter.CountMentions(r.Context(), r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mentionsCountDTO{Unread: unread}, nil, nil
+}
+
+// Marks mentions of current user read, answers with number of unread ones left
+func apiMarkMentionsRead(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body markMentionsBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	unread, err := RedisAdapter.MarkMentionsRead(r.Context(), r.session.login, body.Ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mentionsCountDTO{Unread: unread}, nil, nil
+}
+
+func toMentionDTO(m *redisrpc.Mention) mentionDTO {
+	return mentionDTO{ID: m.Id, MessageID: m.MessageId, Room: m.Room, Author: m.Author, Text: m.Text, Time: m.Time, Parent: m.Parent, Read: m.Read}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	var many []string
+	var manyText []string
+	for n := 0; n < maxMentions+2; n++ {
+		login := "u" + strconv.Itoa(n)
+		manyText = append(manyText, "@"+login)
+		if n < maxMentions {
+			many = append(many, login)
+		}
+	}
+
+	tests := []struct {
+		text   string
+		author string
+		logins []string
+	}{
+		{"hello", "alice", nil},
+		{"@bob hi", "alice", []string{"bob"}},
+		{"hi @bob and @carol", "alice", []string{"bob", "carol"}},
+		{"ask @bob.", "alice", []string{"bob"}},
+		{"ask @bob-", "alice", []string{"bob"}},
+		{"@john.smith here", "alice", []string{"john.smith"}},
+		{"@bob @bob @bob", "alice", []string{"bob"}},
+		{"@alice note to self", "alice", nil},
+		{"mail bob@example.com", "alice", nil},
+		{"@@bob", "alice", nil},
+		{"(@bob)", "alice", []string{"bob"}},
+		{"@. @-", "alice", nil},
+		{strings.Join(manyText, " "), "alice", many},
+	}
+	for _, tt := range tests {
+		if got := parseMentions(tt.text, tt.author); !reflect.DeepEqual(got, tt.logins) {
+			t.Errorf("parseMentions(%q, %q) = %v, want %v", tt.text, tt.author, got, tt.logins)
+		}
+	}
+}
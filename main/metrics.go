@@ -0,0 +1,107 @@
+// Prometheus metrics of main: http handlers and chat activity
+
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chat_http_requests_total",
+		Help: "Number of handled http requests by route, method and status code",
+	}, []string{"route", "method", "code"})
+
+	httpLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chat_http_request_duration_seconds",
+		Help:    "Latency of http requests by route",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route"})
+
+	// Messages per minute: rate(chat_messages_posted_total[1m]) * 60
+	messagesPosted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "chat_messages_posted_total",
+		Help: "Number of chat messages posted by users",
+	})
+)
+
+// Hours kept by messages per hour statistics of admin page
+const statsHours = 24
+
+// Messages posted by hour, since start of this instance
+type hourlyCounter struct {
+	mu     sync.Mutex
+	counts map[int64]int
+}
+
+var messagesPerHour = &hourlyCounter{counts: make(map[int64]int)}
+
+// Counts posted message for metrics and admin page
+func countMessage() {
+	messagesPosted.Inc()
+	messagesPerHour.add(time.Now())
+}
+
+func (c *hourlyCounter) add(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hour := t.Unix() / 3600
+	c.counts[hour]++
+	for h := range c.counts {
+		if h <= hour-statsHours {
+			delete(c.counts, h)
+		}
+	}
+}
+
+// Counts of last n hours, the current one is the last
+func (c *hourlyCounter) last(n int) []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hour := time.Now().Unix() / 3600
+	res := make([]int, n)
+	for i := range res {
+		res[i] = c.counts[hour-int64(n-1-i)]
+	}
+	return res
+}
+
+// Remembers status code written by handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Keeps streaming responses working through the recorder
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Returns registered pattern for request, so metrics are not labeled by every possible path
+func routeOf(next http.Handler, r *http.Request) string {
+	if mux, ok := next.(*http.ServeMux); ok {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			return pattern
+		}
+	}
+	return "other"
+}
+
+// Records request count and latency
+func observeHTTP(route string, r *http.Request, status int, start time.Time) {
+	httpLatency.WithLabelValues(route).Observe(time.Since(start).Seconds())
+	httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(status)).Inc()
+}
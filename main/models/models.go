@@ -5,6 +5,13 @@ type ChatMessage struct {
 	Time    string
 	Name    string
 	Message string
+	Room    string
+	// Message is posted by integration, Name is identity of the bot
+	Bot bool
+	// Display name of the author, empty if author has none
+	Nick string
+	// Id of the message this one replies to, empty for messages of the room stream
+	Parent string
 }
 
 // Will be stored at Redis
@@ -14,4 +21,8 @@ type User struct {
 	Lname string
 	Pass  []byte
 	Role  string
+	// Display name, set by /nick
+	Nick string
+	// User can not log in, set by chatctl
+	Disabled bool
 }
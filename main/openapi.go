@@ -0,0 +1,198 @@
+// Generates OpenAPI 3 spec of api v1 from apiRoutes, schemas come from json tags of body and response types
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	openAPIOnce sync.Once
+	openAPISpec []byte
+)
+
+// Serves OpenAPI spec as json
+func openAPIHandle(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		openAPISpec, _ = json.MarshalIndent(buildOpenAPI(apiRoutes), "", "  ")
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+type object = map[string]interface{}
+
+// Builds spec document, schemas are collected into components by type name
+func buildOpenAPI(routes []apiRoute) object {
+	schemas := object{
+		"Error":      schemaOf(reflect.TypeOf(apiError{}), nil),
+		"Pagination": schemaOf(reflect.TypeOf(apiPagination{}), nil),
+	}
+	paths := object{}
+	for _, rt := range routes {
+		path := apiPrefix + rt.Path
+		item, ok := paths[path].(object)
+		if !ok {
+			item = object{}
+			paths[path] = item
+		}
+		item[strings.ToLower(rt.Method)] = operationOf(rt, schemas)
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "chat_room_go api",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": schemas,
+			"securitySchemes": object{
+				"bearer": object{"type": "http", "scheme": "bearer"},
+				"cookie": object{"type": "apiKey", "in": "cookie", "name": "session"},
+			},
+		},
+	}
+}
+
+// Describes route: parameters, body, envelope of success and error responses
+func operationOf(rt apiRoute, schemas object) object {
+	op := object{
+		"tags":        []string{rt.Tag},
+		"summary":     rt.Summary,
+		"operationId": operationID(rt),
+	}
+
+	params := []object{}
+	for _, p := range splitPath(rt.Path) {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			params = append(params, object{"name": p[1 : len(p)-1], "in": "path", "required": true, "schema": object{"type": "string"}})
+		}
+	}
+	for _, q := range rt.Query {
+		params = append(params, object{"name": q.Name, "in": "query", "description": q.Description, "schema": object{"type": q.Type}})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	if rt.Body != nil {
+		op["requestBody"] = object{
+			"required": true,
+			"content":  object{"application/json": object{"schema": schemaOf(reflect.TypeOf(rt.Body), schemas)}},
+		}
+	}
+	if rt.Auth {
+		op["security"] = []object{{"bearer": []string{}}, {"cookie": []string{}}}
+		op["description"] = "Personal api tokens need \"" + rt.Scope + "\" scope"
+		if rt.Scope == "" {
+			op["description"] = "Not available to personal api tokens"
+		}
+	}
+
+	code := rt.Status
+	if code == 0 {
+		code = http.StatusOK
+	}
+	success := object{"description": http.StatusText(code)}
+	if code != http.StatusNoContent {
+		envelope := object{"request_id": object{"type": "string"}}
+		if rt.Response != nil {
+			t := reflect.TypeOf(rt.Response)
+			envelope["data"] = schemaOf(t, schemas)
+			if t.Kind() == reflect.Slice {
+				envelope["pagination"] = object{"$ref": "#/components/schemas/Pagination"}
+			}
+		}
+		success["content"] = object{"application/json": object{"schema": object{"type": "object", "properties": envelope}}}
+	}
+	op["responses"] = object{
+		strconv.Itoa(code): success,
+		"default": object{
+			"description": "Error",
+			"content": object{"application/json": object{"schema": object{
+				"type": "object",
+				"properties": object{
+					"error":      object{"$ref": "#/components/schemas/Error"},
+					"request_id": object{"type": "string"},
+				},
+			}}},
+		},
+	}
+	return op
+}
+
+// Operation id from method and path: post /rooms/{room}/messages -> postRoomsRoomMessages
+func operationID(rt apiRoute) string {
+	id := strings.ToLower(rt.Method)
+	for _, p := range splitPath(rt.Path) {
+		p = strings.Trim(p, "{}")
+		if p == "" {
+			continue
+		}
+		id += strings.ToUpper(p[:1]) + p[1:]
+	}
+	return id
+}
+
+// Returns schema of type, named structs go to schemas (when given) and are referenced
+func schemaOf(t reflect.Type, schemas object) object {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), schemas)
+	case reflect.String:
+		return object{"type": "string"}
+	case reflect.Bool:
+		return object{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return object{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return object{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return object{"type": "array", "items": schemaOf(t.Elem(), schemas)}
+	case reflect.Struct:
+		props := object{}
+		required := []string{}
+		for n := 0; n < t.NumField(); n++ {
+			f := t.Field(n)
+			tag := f.Tag.Get("json")
+			if f.PkgPath != "" || tag == "-" {
+				continue
+			}
+			parts := strings.Split(tag, ",")
+			name := parts[0]
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = schemaOf(f.Type, schemas)
+			if len(parts) == 1 {
+				required = append(required, name)
+			}
+		}
+		sort.Strings(required)
+		schema := object{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		if schemas == nil {
+			return schema
+		}
+		name := schemaName(t)
+		schemas[name] = schema
+		return object{"$ref": "#/components/schemas/" + name}
+	}
+	return object{}
+}
+
+// Name of schema from go type: roomDTO -> Room, createRoomBody -> CreateRoomBody
+func schemaName(t reflect.Type) string {
+	name := strings.TrimSuffix(t.Name(), "DTO")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
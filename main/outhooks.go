@@ -0,0 +1,104 @@
+// Outgoing webhooks: endpoints registered by users receive signed events of messages and signups
+// Events are published by mongodb and redis microservices, redis microservice delivers them
+
+package main
+
+import (
+	"chat_room_go/microservices/events"
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+)
+
+// Outgoing hook as api returns it, signing secret is known only after creation
+type outgoingHookDTO struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Events        []string `json:"events"`
+	SigningSecret string   `json:"signing_secret,omitempty"`
+	CreatedAt     string   `json:"created_at"`
+}
+
+type createOutgoingHookBody struct {
+	URL string `json:"url"`
+	// Types of events: message.created, message.edited, message.deleted, user.signup
+	Events []string `json:"events"`
+}
+
+// Delivery, that failed all attempts
+type deadLetterDTO struct {
+	ID        string `json:"id"`
+	HookID    string `json:"hook_id"`
+	EventType string `json:"event_type"`
+	// Event as json, exactly as it was sent
+	Event    string `json:"event"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+	FailedAt string `json:"failed_at"`
+}
+
+func toOutgoingHookDTO(h *redisrpc.OutgoingHookInfo) outgoingHookDTO {
+	return outgoingHookDTO{ID: h.Id, URL: h.URL, Events: h.Events, CreatedAt: h.CreatedAt}
+}
+
+func apiListOutgoingHooks(r *apiRequest) (interface{}, *apiPagination, error) {
+	hooks, err := RedisAdapter.ListOutgoingHooks(r.Context(), r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]outgoingHookDTO, 0, len(hooks))
+	for _, h := range hooks {
+		res = append(res, toOutgoingHookDTO(h))
+	}
+	return res, nil, nil
+}
+
+func apiCreateOutgoingHook(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body createOutgoingHookBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	// Signups tell about all users, only admins may watch them
+	for _, e := range body.Events {
+		if e != events.UserSignup {
+			continue
+		}
+		admin, err := isAdmin(r.Context(), r.session.login)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !admin {
+			return nil, nil, errs.New(errs.PermissionDenied, errs.ReasonNotAdmin, "Only admins can subscribe to user.signup")
+		}
+	}
+	hook, err := RedisAdapter.CreateOutgoingHook(r.Context(), &redisrpc.OutgoingHookInfo{Login: r.session.login, URL: body.URL, Events: body.Events})
+	if err != nil {
+		return nil, nil, err
+	}
+	res := toOutgoingHookDTO(hook)
+	res.SigningSecret = hook.SigningSecret
+	return res, nil, nil
+}
+
+func apiDeleteOutgoingHook(r *apiRequest) (interface{}, *apiPagination, error) {
+	return nil, nil, RedisAdapter.DeleteOutgoingHook(r.Context(), r.session.login, r.param("id"))
+}
+
+func apiListDeadLetters(r *apiRequest) (interface{}, *apiPagination, error) {
+	letters, err := RedisAdapter.ListDeadLetters(r.Context(), r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]deadLetterDTO, 0, len(letters))
+	for _, d := range letters {
+		res = append(res, deadLetterDTO{
+			ID:        d.Id,
+			HookID:    d.HookId,
+			EventType: d.EventType,
+			Event:     d.Event,
+			Attempts:  int(d.Attempts),
+			Error:     d.Error,
+			FailedAt:  d.FailedAt,
+		})
+	}
+	return res, nil, nil
+}
@@ -0,0 +1,73 @@
+// Emoji reactions on messages: a user toggles a reaction, mongodb microservice keeps logins of users by emoji
+// Chat page and api get reactions with messages, those of the viewer are marked with "me"
+
+package main
+
+import (
+	mongorpc "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Max length of emoji in runes, sequences with joiners and modifiers take several
+const maxEmojiRunes = 10
+
+// Reaction as api returns it
+type reactionDTO struct {
+	Emoji  string   `json:"emoji"`
+	Count  int32    `json:"count"`
+	Logins []string `json:"logins"`
+	// Current user reacted with this emoji
+	Me bool `json:"me,omitempty"`
+}
+
+type toggleReactionBody struct {
+	Emoji string `json:"emoji"`
+}
+
+// Adds reaction of current user or takes it back, answers with the message
+func apiToggleReaction(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body toggleReactionBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if !validEmoji(body.Emoji) {
+		return nil, nil, errs.Invalid("emoji", "emoji must be a single emoji")
+	}
+	if err := checkMute(r.Context(), r.session.login); err != nil {
+		return nil, nil, err
+	}
+	m, err := MongoAdapter.ToggleReaction(r.Context(), r.param("room"), r.param("id"), r.session.login, body.Emoji)
+	if err != nil {
+		return nil, nil, err
+	}
+	return toMessageDTO(m), nil, nil
+}
+
+// Tells if the text looks like one emoji: short, no letters, digits, spaces or punctuation of ascii
+// At least one symbol is required, joiners and variation selectors alone are invisible
+func validEmoji(e string) bool {
+	if e == "" || utf8.RuneCountInString(e) > maxEmojiRunes {
+		return false
+	}
+	symbol := false
+	for _, c := range e {
+		if c < utf8.RuneSelf || unicode.IsSpace(c) || unicode.IsControl(c) || unicode.IsLetter(c) || unicode.IsDigit(c) {
+			return false
+		}
+		symbol = symbol || unicode.IsSymbol(c)
+	}
+	return symbol
+}
+
+func toReactionDTOs(reactions []*mongorpc.Reaction) []reactionDTO {
+	if len(reactions) == 0 {
+		return nil
+	}
+	res := make([]reactionDTO, 0, len(reactions))
+	for _, r := range reactions {
+		res = append(res, reactionDTO{Emoji: r.Emoji, Count: r.Count, Logins: r.Logins, Me: r.Me})
+	}
+	return res
+}
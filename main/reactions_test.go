@@ -0,0 +1,111 @@
+package main
+
+import (
+	mongorpc "chat_room_go/microservices/mongodb/pb"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestValidEmoji(t *testing.T) {
+	tests := []struct {
+		emoji string
+		valid bool
+	}{
+		{"👍", true},
+		{"❤️", true},
+		{"👨‍👩‍👧", true},
+		{"🇺🇦", true},
+		{"", false},
+		{"a", false},
+		{":+1:", false},
+		{"👍a", false},
+		{"👍 ", false},
+		{"\u200b", false},
+		{"\u200d\ufe0f", false},
+		{"Ж", false},
+		{"٣", false},
+		{"👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍", false},
+	}
+	for _, tt := range tests {
+		if got := validEmoji(tt.emoji); got != tt.valid {
+			t.Errorf("validEmoji(%q) = %v, want %v", tt.emoji, got, tt.valid)
+		}
+	}
+}
+
+// Reactions of one message in mongodb: logins by emoji
+type fakeReactions struct {
+	mongorpc.ReactionsClient
+	logins map[string][]string
+}
+
+func (f *fakeReactions) ToggleReaction(ctx context.Context, in *mongorpc.ToggleReactionRequest, opts ...grpc.CallOption) (*mongorpc.MessageInfo, error) {
+	logins := f.logins[in.Emoji]
+	n := 0
+	for n < len(logins) && logins[n] != in.Login {
+		n++
+	}
+	if n < len(logins) {
+		logins = append(logins[:n], logins[n+1:]...)
+	} else {
+		logins = append(logins, in.Login)
+	}
+	f.logins[in.Emoji] = logins
+
+	m := &mongorpc.MessageInfo{Id: in.Id, Room: in.Room, Name: "bob", Message: "hello"}
+	for emoji, logins := range f.logins {
+		if len(logins) == 0 {
+			continue
+		}
+		r := &mongorpc.Reaction{Emoji: emoji, Count: int32(len(logins)), Logins: logins}
+		for _, l := range logins {
+			r.Me = r.Me || l == in.Login
+		}
+		m.Reactions = append(m.Reactions, r)
+	}
+	return m, nil
+}
+
+func TestAPIToggleReaction(t *testing.T) {
+	savedMongo, savedRedis := MongoAdapter, RedisAdapter
+	defer func() { MongoAdapter, RedisAdapter = savedMongo, savedRedis }()
+	MongoAdapter = grpcMongoAdapter{reactionsClient: &fakeReactions{logins: map[string][]string{"👍": {"bob"}}}}
+	RedisAdapter = grpcRedisAdapter{
+		getterSessionClient: &fakeSessions{},
+		moderationClient:    &fakeModeration{kinds: map[string]string{"max": restrictionMute}},
+	}
+
+	tests := []struct {
+		name  string
+		login string
+		emoji string
+		code  int
+		want  []reactionDTO
+	}{
+		{"adds reaction", "ann", "👍", http.StatusOK, []reactionDTO{{Emoji: "👍", Count: 2, Logins: []string{"bob", "ann"}, Me: true}}},
+		{"takes it back", "ann", "👍", http.StatusOK, []reactionDTO{{Emoji: "👍", Count: 1, Logins: []string{"bob"}}}},
+		{"not an emoji", "ann", "+1", http.StatusBadRequest, nil},
+		{"muted can not react", "max", "👍", http.StatusForbidden, nil},
+	}
+	for _, tt := range tests {
+		body, _ := json.Marshal(toggleReactionBody{Emoji: tt.emoji})
+		code, resp := serveAPI(t, tt.login, http.MethodPost, "/rooms/general/messages/m1/reactions", string(body))
+		if code != tt.code {
+			t.Fatalf("%s: status %d, want %d: %+v", tt.name, code, tt.code, resp.Error)
+		}
+		if code != http.StatusOK {
+			continue
+		}
+		data, _ := json.Marshal(resp.Data)
+		var m messageDTO
+		json.Unmarshal(data, &m)
+		if !reflect.DeepEqual(m.Reactions, tt.want) {
+			t.Errorf("%s: reactions %+v, want %+v", tt.name, m.Reactions, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Read receipts: redis microservice keeps last read message of every user in every room, client acknowledges messages it showed
+// Unread messages after the marker are counted by mongodb microservice for badges of rooms
+
+package main
+
+import (
+	"chat_room_go/utils/errs"
+	"regexp"
+)
+
+// Ids of messages are ids of mongodb objects
+var messageIDPattern = regexp.MustCompile(`^[0-9a-f]{24}$`)
+
+// Unread messages of the room for current user
+type unreadDTO struct {
+	Room string `json:"room"`
+	// Id of the last read message, empty if the room was never read
+	LastRead string `json:"last_read,omitempty"`
+	// Messages of the room stream from other users after the last read one, counted up to 100
+	Unread int32 `json:"unread"`
+}
+
+type markReadBody struct {
+	// Id of the last message the client showed
+	MessageID string `json:"message_id"`
+}
+
+// User, who has read the room up to the message or further
+type readerDTO struct {
+	Login    string `json:"login"`
+	LastRead string `json:"last_read"`
+}
+
+// Lists all rooms with unread messages of current user, in order of rooms
+func apiListUnread(r *apiRequest) (interface{}, *apiPagination, error) {
+	rooms, err := MongoAdapter.ListRooms(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, 0, len(rooms))
+	for _, room := range rooms {
+		names = append(names, room.Name)
+	}
+	res, err := unreadOf(r, names)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, nil, nil
+}
+
+// Moves read marker of current user in the room, answers with unread messages left in the room
+func apiMarkRead(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body markReadBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if !messageIDPattern.MatchString(body.MessageID) {
+		return nil, nil, errs.Invalid("message_id", "message_id is not a message id")
+	}
+	room := r.param("room")
+	// Marker points to a message of the room stream, so unread are counted after it
+	m, err := MongoAdapter.Get(r.Context(), room, body.MessageID, r.session.login)
+	if err != nil {
+		return nil, nil, err
+	}
+	if m.Parent != "" {
+		return nil, nil, errs.Invalid("message_id", "message_id is a reply, threads have no read marker")
+	}
+	if _, err := RedisAdapter.MarkRead(r.Context(), r.session.login, room, body.MessageID); err != nil {
+		return nil, nil, err
+	}
+	res, err := unreadOf(r, []string{room})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res[0], nil, nil
+}
+
+// Lists users, who have read the room up to the message or further
+func apiListReaders(r *apiRequest) (interface{}, *apiPagination, error) {
+	if !messageIDPattern.MatchString(r.param("id")) {
+		return nil, nil, errs.New(errs.NotFound, errs.ReasonMessageNotFound, "Message not found")
+	}
+	markers, err := RedisAdapter.ListReaders(r.Context(), r.param("room"), r.param("id"))
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]readerDTO, 0, len(markers))
+	for _, m := range markers {
+		res = append(res, readerDTO{Login: m.Login, LastRead: m.MessageId})
+	}
+	return res, nil, nil
+}
+
+// Returns unread messages of current user in the rooms
+func unreadOf(r *apiRequest, rooms []string) ([]unreadDTO, error) {
+	markers, err := RedisAdapter.GetReadMarkers(r.Context(), r.session.login)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := MongoAdapter.CountUnread(r.Context(), markers, rooms, r.session.login)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]unreadDTO, 0, len(rooms))
+	for _, room := range rooms {
+		res = append(res, unreadDTO{Room: room, LastRead: markers[room], Unread: unread[room]})
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+// Reports of messages: users report messages with a reason, admins resolve them on reports page of admin pages
+// Decision stays on the report, so resolved reports are the history of moderation
+
+package main
+
+import (
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"unicode/utf8"
+)
+
+// Decisions of moderator on report
+const (
+	decisionDismiss = "dismiss"
+	decisionDelete  = "delete"
+	decisionMute    = "mute"
+	decisionBan     = "ban"
+)
+
+var reportDecisions = []string{decisionDismiss, decisionDelete, decisionMute, decisionBan}
+
+// Max length of reason of report
+const maxReportReason = 500
+
+// Report as api returns it to the reporter
+type reportDTO struct {
+	ID        string `json:"id"`
+	MessageID string `json:"message_id"`
+	Room      string `json:"room"`
+	Reason    string `json:"reason"`
+	Time      string `json:"time"`
+	Status    string `json:"status"`
+}
+
+type createReportBody struct {
+	Reason string `json:"reason"`
+}
+
+func apiCreateReport(r *apiRequest) (interface{}, *apiPagination, error) {
+	var body createReportBody
+	if err := r.decode(&body); err != nil {
+		return nil, nil, err
+	}
+	if body.Reason == "" || utf8.RuneCountInString(body.Reason) > maxReportReason {
+		return nil, nil, errs.Invalid("reason", "reason must be 1-500 characters")
+	}
+	report, err := MongoAdapter.CreateReport(r.Context(), r.param("room"), r.param("id"), r.session.login, body.Reason)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reportDTO{ID: report.Id, MessageID: report.MessageId, Room: report.Room, Reason: report.Reason, Time: report.Time, Status: report.Status}, nil, nil
+}
+
+// Resolves the report and other reports of the message, then applies decision to the stored message or its author
+// Resolving goes first, so decision of a report is applied once, even if two admins resolve it
+// Mute and ban last for seconds, forever if 0
+func resolveReport(ctx context.Context, admin, id, decision string, seconds int64) error {
+	if !contains(reportDecisions, decision) {
+		return errs.Invalid("decision", "Unknown decision")
+	}
+	report, err := MongoAdapter.GetReport(ctx, id)
+	if err != nil {
+		return err
+	}
+	if decision == decisionMute || decision == decisionBan {
+		// Name of a bot may be a login of some user, who did not post the message
+		if report.Bot {
+			return errs.Invalid("decision", "Author of the message is a bot, delete the message instead")
+		}
+		if report.Author == admin {
+			return errs.Invalid("login", "You can not restrict yourself")
+		}
+	}
+	if report, err = MongoAdapter.ResolveReport(ctx, id, decision, admin); err != nil {
+		return err
+	}
+
+	switch decision {
+	case decisionDelete:
+		_, err = MongoAdapter.Delete(ctx, report.Room, report.MessageId, "")
+		if err == nil {
+			dropMentions(ctx, report.MessageId)
+		}
+		// Author could delete it already
+		if errs.Reason(err) == errs.ReasonMessageNotFound {
+			err = nil
+		}
+	case decisionMute, decisionBan:
+		kind := restrictionMute
+		if decision == decisionBan {
+			kind = restrictionBan
+			recentSessions.forgetUser(report.Author)
+		}
+		_, err = RedisAdapter.SetRestriction(ctx, &redisrpc.Restriction{Login: report.Author, Kind: kind, Reason: "Reported: " + report.Reason, By: admin}, seconds)
+	}
+	if err != nil {
+		return err
+	}
+	logs.Ctx(ctx).Infof("Report %s of message %s by %s is resolved by %s: %s", report.Id, report.MessageId, report.Author, admin, decision)
+	return nil
+}
@@ -0,0 +1,316 @@
+// Client side resilience for microservice calls: retries of idempotent rpcs and circuit breaker per service
+// When microservice is down main keeps serving recently seen sessions in read-only mode
+
+package main
+
+import (
+	redisrpc "chat_room_go/microservices/redis/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/logs"
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rpcs, that only read and are safe to repeat
+// Writes are never retried: after DeadlineExceeded the first attempt may be applied already
+var idempotentMethods = map[string]bool{
+	"/mongogrpc.Reader/Read":                     true,
+	"/mongogrpc.Reader/Thread":                   true,
+	"/mongogrpc.Reader/Get":                      true,
+	"/mongogrpc.Reader/CountUnread":              true,
+	"/mongogrpc.Rooms/GetRoom":                   true,
+	"/mongogrpc.Rooms/ListRooms":                 true,
+	"/mongogrpc.Reports/ListReports":             true,
+	"/mongogrpc.Reports/GetReport":               true,
+	"/redisgrpc.Reader/Read":                     true,
+	"/redisgrpc.GetterSession/GetSession":        true,
+	"/redisgrpc.Tokens/CheckToken":               true,
+	"/redisgrpc.Tokens/ListTokens":               true,
+	"/redisgrpc.IncomingHooks/GetIncomingHook":   true,
+	"/redisgrpc.IncomingHooks/ListIncomingHooks": true,
+	"/redisgrpc.OutgoingHooks/ListOutgoingHooks": true,
+	"/redisgrpc.OutgoingHooks/ListDeadLetters":   true,
+	"/redisgrpc.Admin/ListUsers":                 true,
+	"/redisgrpc.Admin/ListSessions":              true,
+	"/redisgrpc.Moderation/GetRestriction":       true,
+	"/redisgrpc.Moderation/ListRestrictions":     true,
+	"/redisgrpc.Mentions/ListMentions":           true,
+	"/redisgrpc.Mentions/CountMentions":          true,
+	"/redisgrpc.ReadMarkers/GetReadMarkers":      true,
+	"/redisgrpc.ReadMarkers/ListReaders":         true,
+	"/grpcconnector.Reader/Recent":               true,
+	"/grpcconnector.Audit/ListAudit":             true,
+}
+
+// Messages for users, when chat can not save messages or can not serve at all
+const (
+	readOnlyMessage    = "Chat is temporarily read-only, please try again later"
+	unavailableMessage = "Chat is temporarily unavailable, please try again later"
+)
+
+// Response header, that tells front that chat is served in read-only mode
+const readOnlyHeader = "X-Chat-Read-Only"
+
+var breakerOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "chat_circuit_breaker_open",
+	Help: "1 if circuit breaker of microservice is open",
+}, []string{"service"})
+
+// Returned without calling microservice, while its breaker is open
+var errCircuitOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// Tells if error means that microservice is unavailable, not that request was wrong
+// ResourceExhausted is a rate limit of the user or of the service, it answered, so it is not a failure
+func isUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpened
+	breakerHalfOpen
+)
+
+// Opens after threshold failures in a row, after cooldown lets one call through to probe the service
+type circuitBreaker struct {
+	service   string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(service string) *circuitBreaker {
+	return &circuitBreaker{
+		service:   service,
+		threshold: config.Config.Resilience.BreakerThreshold,
+		cooldown:  time.Duration(config.Config.Resilience.BreakerCooldown) * time.Second,
+	}
+}
+
+// Tells if call may go to microservice
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpened:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// Probe is in flight already
+		return false
+	}
+	return true
+}
+
+// Records result of the call
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !isUnavailable(err) {
+		if b.state != breakerClosed {
+			logs.Logger.Infof("Circuit breaker of %s service closed", b.service)
+			breakerOpen.WithLabelValues(b.service).Set(0)
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpened {
+			logs.Logger.Warnf("Circuit breaker of %s service opened after %d failures", b.service, b.failures)
+			breakerOpen.WithLabelValues(b.service).Set(1)
+		}
+		b.state = breakerOpened
+		b.openedAt = time.Now()
+	}
+}
+
+// Delay before retry number attempt (from 0): exponential with full jitter
+func backoff(attempt int) time.Duration {
+	base := time.Duration(config.Config.Resilience.BackoffBase) * time.Millisecond
+	max := time.Duration(config.Config.Resilience.BackoffMax) * time.Millisecond
+	d := base << uint(attempt)
+	if d > max || d <= 0 {
+		d = max
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// Interceptor that fails fast with open breaker and retries idempotent rpcs within deadline of the call
+func resilienceInterceptor(b *circuitBreaker) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		retries := 0
+		if idempotentMethods[method] {
+			retries = config.Config.Resilience.MaxRetries
+		}
+
+		for attempt := 0; ; attempt++ {
+			if !b.allow() {
+				return errCircuitOpen
+			}
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			b.record(err)
+			if err == nil || !isUnavailable(err) || attempt >= retries {
+				return err
+			}
+
+			logs.Ctx(ctx).Warnf("Retrying %s after error: %s", method, err)
+			timer := time.NewTimer(backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// Sessions, confirmed by redis recently. Used only for reading while redis is unavailable
+type sessionCache struct {
+	mu       sync.Mutex
+	sessions map[string]cachedSession
+	// Last time expired sessions were dropped
+	swept time.Time
+}
+
+type cachedSession struct {
+	login string
+	// Scopes and id of api token, nil and empty for login sessions
+	scopes  []string
+	tokenID string
+	seen    time.Time
+}
+
+var recentSessions = &sessionCache{sessions: make(map[string]cachedSession)}
+
+// How often remember drops expired sessions of the cache
+const sessionCacheSweep = time.Minute
+
+// Remembers confirmed session, drops expired ones once in sessionCacheSweep
+func (c *sessionCache) remember(sessionId, login string) {
+	c.put(sessionId, cachedSession{login: login})
+}
+
+// Remembers confirmed api token by its secret
+func (c *sessionCache) rememberToken(secret string, token *redisrpc.TokenInfo) {
+	c.put(secret, cachedSession{login: token.Login, scopes: token.Scopes, tokenID: token.Id})
+}
+
+func (c *sessionCache) put(sessionId string, s cachedSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(c.swept) >= sessionCacheSweep {
+		c.swept = now
+		for id, s := range c.sessions {
+			if now.Sub(s.seen) > time.Duration(sessionLength)*time.Second {
+				delete(c.sessions, id)
+			}
+		}
+	}
+	s.seen = now
+	c.sessions[sessionId] = s
+}
+
+// Returns session, if it was confirmed within session length, expired session is dropped
+func (c *sessionCache) lookup(sessionId string) (cachedSession, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.sessions[sessionId]
+	if !ok {
+		return cachedSession{}, false
+	}
+	if time.Since(s.seen) > time.Duration(sessionLength)*time.Second {
+		delete(c.sessions, sessionId)
+		return cachedSession{}, false
+	}
+	return s, true
+}
+
+// Forgets session after logout
+func (c *sessionCache) forget(sessionId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, sessionId)
+}
+
+// Forgets api token after it is revoked, cache knows its secret only
+func (c *sessionCache) forgetToken(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for secret, s := range c.sessions {
+		if s.tokenID == id {
+			delete(c.sessions, secret)
+		}
+	}
+}
+
+// Forgets all sessions of the user, after admin ended them
+func (c *sessionCache) forgetUser(login string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, s := range c.sessions {
+		if s.login == login {
+			delete(c.sessions, id)
+		}
+	}
+}
+
+type readOnlyKey struct{}
+
+// Marks request as served in read-only mode
+func withReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// Tells if request is served in read-only mode
+func isReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// Tells user that messages can not be posted now
+func writeReadOnly(w http.ResponseWriter) {
+	w.Header().Set(readOnlyHeader, "true")
+	w.Header().Set("Retry-After", strconv.Itoa(config.Config.Resilience.BreakerCooldown))
+	http.Error(w, readOnlyMessage, http.StatusServiceUnavailable)
+}
+
+// Tells user that chat can not serve request now
+func writeUnavailable(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", strconv.Itoa(config.Config.Resilience.BreakerCooldown))
+	http.Error(w, unavailableMessage, http.StatusServiceUnavailable)
+}
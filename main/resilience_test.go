@@ -0,0 +1,208 @@
+package main
+
+import (
+	config "chat_room_go/utils/conf"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsUnavailable(t *testing.T) {
+	tests := []struct {
+		err         error
+		unavailable bool
+	}{
+		{nil, false},
+		{status.Error(codes.Unavailable, ""), true},
+		{status.Error(codes.DeadlineExceeded, ""), true},
+		{errCircuitOpen, true},
+		{status.Error(codes.ResourceExhausted, ""), false},
+		{status.Error(codes.Canceled, ""), false},
+		{status.Error(codes.NotFound, ""), false},
+		{status.Error(codes.InvalidArgument, ""), false},
+		{status.Error(codes.PermissionDenied, ""), false},
+		{status.Error(codes.Internal, ""), false},
+	}
+	for _, tt := range tests {
+		if got := isUnavailable(tt.err); got != tt.unavailable {
+			t.Errorf("isUnavailable(%v) = %v, want %v", tt.err, got, tt.unavailable)
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "")
+	notFound := status.Error(codes.NotFound, "")
+	limited := status.Error(codes.ResourceExhausted, "")
+
+	// Steps are applied in order: call result is recorded, then state and allow are checked
+	type step struct {
+		err      error
+		cooldown bool
+		state    breakerState
+		allow    bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"stays closed below threshold", []step{
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerClosed, allow: true},
+		}},
+		{"opens at threshold", []step{
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerOpened, allow: false},
+		}},
+		{"success resets failures", []step{
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: nil, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerClosed, allow: true},
+		}},
+		{"client errors are not failures", []step{
+			{err: notFound, state: breakerClosed, allow: true},
+			{err: limited, state: breakerClosed, allow: true},
+			{err: limited, state: breakerClosed, allow: true},
+			{err: limited, state: breakerClosed, allow: true},
+		}},
+		{"half-open after cooldown lets one probe", []step{
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, cooldown: true, state: breakerHalfOpen, allow: false},
+		}},
+		{"failed probe opens again", []step{
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, cooldown: true, state: breakerHalfOpen, allow: false},
+			{err: unavailable, state: breakerOpened, allow: false},
+		}},
+		{"successful probe closes", []step{
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, state: breakerClosed, allow: true},
+			{err: unavailable, cooldown: true, state: breakerHalfOpen, allow: false},
+			{err: nil, state: breakerClosed, allow: true},
+		}},
+	}
+	for _, tt := range tests {
+		b := &circuitBreaker{service: "test", threshold: 3, cooldown: time.Minute}
+		for n, s := range tt.steps {
+			b.record(s.err)
+			if s.cooldown {
+				b.openedAt = time.Now().Add(-b.cooldown)
+				// The first call after cooldown is the probe
+				if !b.allow() {
+					t.Fatalf("%s: step %d: probe is not allowed", tt.name, n)
+				}
+			}
+			if b.state != s.state {
+				t.Fatalf("%s: step %d: state %d, want %d", tt.name, n, b.state, s.state)
+			}
+			if got := b.allow(); got != s.allow {
+				t.Fatalf("%s: step %d: allow = %v, want %v", tt.name, n, got, s.allow)
+			}
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	config.Config.Resilience.BackoffBase = 100
+	config.Config.Resilience.BackoffMax = 1000
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{2, 400 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, time.Second},
+		{10, time.Second},
+		// Shift overflows, delay is capped anyway
+		{70, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if d := backoff(tt.attempt); d < 0 || d > tt.max {
+				t.Fatalf("backoff(%d) = %v, want 0..%v", tt.attempt, d, tt.max)
+			}
+		}
+	}
+}
+
+func TestResilienceInterceptor(t *testing.T) {
+	config.Config.Resilience.MaxRetries = 2
+	config.Config.Resilience.BackoffBase = 1
+	config.Config.Resilience.BackoffMax = 1
+	unavailable := status.Error(codes.Unavailable, "")
+	deadline := status.Error(codes.DeadlineExceeded, "")
+	notFound := status.Error(codes.NotFound, "")
+
+	tests := []struct {
+		name   string
+		method string
+		// Errors of calls in order, calls after them succeed
+		errs  []error
+		open  bool
+		calls int
+		err   error
+	}{
+		{"read is retried", "/redisgrpc.Reader/Read", []error{unavailable, unavailable}, false, 3, nil},
+		{"read gives up after max retries", "/redisgrpc.Reader/Read", []error{unavailable, unavailable, unavailable}, false, 3, unavailable},
+		{"read is not retried on answer", "/redisgrpc.Reader/Read", []error{notFound}, false, 1, notFound},
+		{"write is not retried", "/redisgrpc.Writer/SetNick", []error{unavailable}, false, 1, unavailable},
+		{"write is not retried after deadline", "/mongogrpc.Writer/Write", []error{deadline}, false, 1, deadline},
+		{"open breaker fails fast", "/redisgrpc.Reader/Read", nil, true, 0, errCircuitOpen},
+	}
+	for _, tt := range tests {
+		b := &circuitBreaker{service: "test", threshold: 10, cooldown: time.Minute}
+		if tt.open {
+			b.state = breakerOpened
+			b.openedAt = time.Now()
+		}
+		calls := 0
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls++
+			if calls <= len(tt.errs) {
+				return tt.errs[calls-1]
+			}
+			return nil
+		}
+		err := resilienceInterceptor(b)(context.Background(), tt.method, nil, nil, nil, invoker)
+		if err != tt.err || calls != tt.calls {
+			t.Errorf("%s: %d calls, error %v, want %d calls, error %v", tt.name, calls, err, tt.calls, tt.err)
+		}
+	}
+}
+
+func TestAPIReadOnly(t *testing.T) {
+	saved := RedisAdapter
+	defer func() { RedisAdapter = saved }()
+	RedisAdapter = grpcRedisAdapter{getterSessionClient: &fakeSessions{err: status.Error(codes.Unavailable, "redis is down")}}
+	recentSessions.remember("ann", "ann")
+	defer recentSessions.forget("ann")
+
+	tests := []struct {
+		name    string
+		login   string
+		message string
+	}{
+		{"recent session can not post", "ann", readOnlyMessage},
+		{"unknown session is not served", "bob", unavailableMessage},
+	}
+	for _, tt := range tests {
+		code, resp := serveAPI(t, tt.login, http.MethodPost, "/rooms/general/messages", `{"text": "hello"}`)
+		if code != http.StatusServiceUnavailable {
+			t.Errorf("%s: status %d, want %d", tt.name, code, http.StatusServiceUnavailable)
+		}
+		if resp.Error == nil || resp.Error.Message != tt.message {
+			t.Errorf("%s: error %+v, want message %q", tt.name, resp.Error, tt.message)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Bans, mutes and timeouts of users, set by admins and stored by redis microservice
+// Ban is checked on login and on every request, mute and timeout before posting
+// When redis fails, reading goes on (as in read-only mode), posting and reacting fail
+
+package main
+
+import (
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+)
+
+// Kinds of restrictions, as redis microservice stores them
+const (
+	// User can not log in
+	restrictionBan = "ban"
+	// User can read, but can not post
+	restrictionMute = "mute"
+	// Mute, that expires
+	restrictionTimeout = "timeout"
+)
+
+// Kinds of restrictions, that admin can set
+var restrictionKinds = []string{restrictionBan, restrictionMute, restrictionTimeout}
+
+// Returns PermissionDenied error, if the user is banned
+// Redis failure does not lock users out, it is logged and the user is let in
+func checkBan(ctx context.Context, login string) error {
+	r, err := RedisAdapter.GetRestriction(ctx, login)
+	if err != nil {
+		logs.Ctx(ctx).Error("Ban is not checked: ", err)
+		return nil
+	}
+	if r.Kind != restrictionBan {
+		return nil
+	}
+	return errs.New(errs.PermissionDenied, errs.ReasonBanned, restrictionMessage("You are banned", r))
+}
+
+// Returns PermissionDenied error, if the user can not post: muted, timed out or banned
+// Redis failure is returned, so muted users do not post, while it is down
+func checkMute(ctx context.Context, login string) error {
+	r, err := RedisAdapter.GetRestriction(ctx, login)
+	if err != nil {
+		return err
+	}
+	switch r.Kind {
+	case "":
+		return nil
+	case restrictionBan:
+		return errs.New(errs.PermissionDenied, errs.ReasonBanned, restrictionMessage("You are banned", r))
+	case restrictionTimeout:
+		return errs.New(errs.PermissionDenied, errs.ReasonMuted, restrictionMessage("You are timed out", r))
+	}
+	return errs.New(errs.PermissionDenied, errs.ReasonMuted, restrictionMessage("You are muted", r))
+}
+
+// Tells user what the restriction is, until when and why
+func restrictionMessage(prefix string, r *redisrpc.Restriction) string {
+	if r.ExpiresAt != "" {
+		prefix += " until " + r.ExpiresAt
+	}
+	if r.Reason != "" {
+		prefix += ": " + r.Reason
+	}
+	return prefix
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sessions of redis: session id is the login, error fails every lookup
+type fakeSessions struct {
+	redisrpc.GetterSessionClient
+	err error
+}
+
+func (f *fakeSessions) GetSession(ctx context.Context, in *redisrpc.GetSessionRequest, opts ...grpc.CallOption) (*redisrpc.GetSessionResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &redisrpc.GetSessionResponse{UserName: in.SessionId}, nil
+}
+
+// Restrictions of redis by login, error fails every lookup
+type fakeModeration struct {
+	redisrpc.ModerationClient
+	kinds map[string]string
+	err   error
+}
+
+func (f *fakeModeration) GetRestriction(ctx context.Context, in *redisrpc.GetRestrictionRequest, opts ...grpc.CallOption) (*redisrpc.Restriction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &redisrpc.Restriction{Login: in.Login, Kind: f.kinds[in.Login], Reason: "spam"}, nil
+}
+
+// Serves api request of the user with session, returns status and envelope of the response
+func serveAPI(t *testing.T, login, method, path, body string) (int, apiResponse) {
+	r := httptest.NewRequest(method, apiPrefix+path, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer "+login)
+	w := httptest.NewRecorder()
+	(&apiRouter{routes: apiRoutes}).ServeHTTP(w, r)
+
+	var resp apiResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%s %s: response is not json: %q", method, path, w.Body.String())
+	}
+	return w.Code, resp
+}
+
+func TestAPIRestrictions(t *testing.T) {
+	saved := RedisAdapter
+	defer func() { RedisAdapter = saved }()
+
+	tests := []struct {
+		name   string
+		login  string
+		method string
+		path   string
+		err    error
+		code   int
+		reason string
+	}{
+		// Ban is checked before the handler, which has no mongodb here
+		{"banned can not read", "bob", http.MethodGet, "/rooms/general", nil, http.StatusForbidden, errs.ReasonBanned},
+		{"banned can not post", "bob", http.MethodPost, "/rooms/general/messages", nil, http.StatusForbidden, errs.ReasonBanned},
+		{"muted can not post", "max", http.MethodPost, "/rooms/general/messages", nil, http.StatusForbidden, errs.ReasonMuted},
+		{"timed out can not post", "tim", http.MethodPost, "/rooms/general/messages", nil, http.StatusForbidden, errs.ReasonMuted},
+		{"redis failure lets in, but not posts", "ann", http.MethodPost, "/rooms/general/messages", status.Error(codes.Unavailable, "redis is down"), http.StatusServiceUnavailable, ""},
+	}
+	for _, tt := range tests {
+		RedisAdapter = grpcRedisAdapter{
+			getterSessionClient: &fakeSessions{},
+			moderationClient: &fakeModeration{
+				kinds: map[string]string{"bob": restrictionBan, "max": restrictionMute, "tim": restrictionTimeout},
+				err:   tt.err,
+			},
+		}
+		code, resp := serveAPI(t, tt.login, tt.method, tt.path, `{"text": "hello"}`)
+		if code != tt.code {
+			t.Errorf("%s: status %d, want %d", tt.name, code, tt.code)
+		}
+		if resp.Error == nil || resp.Error.Reason != tt.reason {
+			t.Errorf("%s: error %+v, want reason %q", tt.name, resp.Error, tt.reason)
+		}
+	}
+}
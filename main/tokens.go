@@ -0,0 +1,189 @@
+// Personal api tokens: bots and scripts authenticate with "Authorization: Bearer <token>" instead of session
+// Tokens are created and revoked by user on settings page or via api, redis microservice keeps only their hashes
+
+package main
+
+import (
+	redisservice "chat_room_go/microservices/redis"
+	redisrpc "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+// Scopes of api tokens
+const (
+	// Read rooms, messages and users
+	scopeRead = "read"
+	// Post messages and create rooms
+	scopeWrite = "write"
+)
+
+var tokenScopes = []string{scopeRead, scopeWrite}
+
+// Max length of token name
+const maxTokenName = 64
+
+// Tells if bearer value is api token, not session id
+func isAPIToken(id string) bool {
+	return strings.HasPrefix(id, redisservice.TokenPrefix)
+}
+
+// Gets session of api token, nil if there is no such token
+// When redis is unavailable recently checked token still can read, if it has read scope
+func getTokenSession(r *http.Request, secret string) (*session, error) {
+	token, err := RedisAdapter.CheckToken(r.Context(), secret)
+	if isUnavailable(err) {
+		cached, ok := recentSessions.lookup(secret)
+		if !ok || !hasScope(cached.scopes, scopeRead) {
+			return nil, err
+		}
+		logs.Ctx(r.Context()).Warn("Redis is unavailable, serving api token in read-only mode: ", err)
+		return &session{id: secret, login: cached.login, readOnly: true, apiToken: true, scopes: []string{scopeRead}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, nil
+	}
+	recentSessions.rememberToken(secret, token)
+
+	return &session{id: secret, login: token.Login, apiToken: true, scopes: token.Scopes}, nil
+}
+
+// Tells if session may do things of the scope, browser and login sessions may do everything,
+// empty scope means action is not available to api tokens
+func (s *session) allows(scope string) bool {
+	if !s.apiToken {
+		return true
+	}
+	if scope == "" {
+		return false
+	}
+	return hasScope(s.scopes, scope)
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, sc := range scopes {
+		if sc == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Scope that site page needs: reading for GET, writing otherwise
+func methodScope(r *http.Request) string {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return scopeRead
+	}
+	return scopeWrite
+}
+
+// Error for api token without needed scope
+func errScope(scope string) error {
+	if scope == "" {
+		return errs.New(errs.PermissionDenied, errs.ReasonScopeMissing, "Not available to api tokens, use login session")
+	}
+	return errs.New(errs.PermissionDenied, errs.ReasonScopeMissing, "Api token has no \""+scope+"\" scope")
+}
+
+// Checks name and scopes of new token
+func validateToken(name string, scopes []string) error {
+	if name == "" || len(name) > maxTokenName {
+		return errs.Invalid("name", "name must be 1-64 characters")
+	}
+	if len(scopes) == 0 {
+		return errs.Invalid("scopes", "at least one scope is required")
+	}
+	for _, sc := range scopes {
+		if sc != scopeRead && sc != scopeWrite {
+			return errs.Invalid("scopes", "unknown scope \""+sc+"\", allowed: "+strings.Join(tokenScopes, ", "))
+		}
+	}
+	return nil
+}
+
+// Data of settings page
+type tokensPage struct {
+	Tokens []*redisrpc.TokenInfo
+	Scopes []string
+	// Secret of just created token, shown only once
+	Secret string
+	Error  string
+	// Token of post forms
+	CSRF string
+}
+
+// Handles settings page of api tokens: list, create (action=create) and revoke (action=revoke)
+func tokensHandle(w http.ResponseWriter, r *http.Request) {
+	sess, err := getSession(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if sess == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	if sess.apiToken {
+		http.Error(w, "Api tokens can not manage tokens", http.StatusForbidden)
+		return
+	}
+
+	page := tokensPage{Scopes: tokenScopes, CSRF: csrfToken(sess)}
+	code := http.StatusOK
+	if r.Method == http.MethodPost {
+		if isReadOnly(r.Context()) {
+			writeReadOnly(w)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Malformed form", http.StatusBadRequest)
+			return
+		}
+		if !validCSRF(r, sess) {
+			writeError(w, r, errs.New(errs.PermissionDenied, errs.ReasonBadCSRF, "Form is expired, reload the page"))
+			return
+		}
+		switch r.PostForm.Get("action") {
+		case "create":
+			name, scopes := r.PostForm.Get("name"), r.PostForm["scope"]
+			err = validateToken(name, scopes)
+			if err == nil {
+				var token *redisrpc.TokenInfo
+				token, page.Secret, err = RedisAdapter.CreateToken(r.Context(), sess.login, name, scopes)
+				audit(r.Context(), sess.login, auditCreateToken, token.GetId(), tokenDetails(name, scopes), err)
+			}
+		case "revoke":
+			err = RedisAdapter.RevokeToken(r.Context(), sess.login, r.PostForm.Get("id"))
+			if err == nil {
+				recentSessions.forgetToken(r.PostForm.Get("id"))
+			}
+			audit(r.Context(), sess.login, auditRevokeToken, r.PostForm.Get("id"), "", err)
+		default:
+			http.Error(w, "Unknown action", http.StatusBadRequest)
+			return
+		}
+		if code = errs.HTTPStatus(err); code >= http.StatusInternalServerError {
+			writeError(w, r, err)
+			return
+		} else if err != nil {
+			page.Error = status.Convert(err).Message()
+		}
+	}
+
+	page.Tokens, err = RedisAdapter.ListTokens(r.Context(), sess.login)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(code)
+	err = tpl.ExecuteTemplate(w, "tokens.gohtml", page)
+	if err != nil {
+		logs.Ctx(r.Context()).Error(err)
+	}
+}
@@ -0,0 +1,55 @@
+// Request ids and tracing: every http request gets id, it goes to logs and to microservices via grpc metadata
+
+package main
+
+import (
+	"chat_room_go/utils/logs"
+	"chat_room_go/utils/tracing"
+	"context"
+	"net/http"
+
+	uuid "github.com/satori/go.uuid"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// Response header with id of the request
+const requestIDHeader = "X-Request-Id"
+
+// Generates request id, remembers address of the client, starts server span for the request
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := uuid.NewV4().String()
+		ctx := withClientIP(logs.WithRequestID(r.Context(), requestID), r)
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+r.URL.Path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("request.id", requestID)),
+		)
+		defer span.End()
+
+		w.Header().Set(requestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Starts client span for every grpc call, trace context goes to the microservice in metadata
+func tracingClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, span := tracing.Tracer().Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	err := invoker(tracing.Inject(ctx), method, req, reply, cc, opts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+// Audit of chatctl: changes of users, sessions and messages go to audit log of clickhouse microservice, as actions of admin pages do
+// Actor is "chatctl:<user of the system>", failure of audit is printed and does not fail the command
+
+package main
+
+import (
+	clickhouseconnector "chat_room_go/microservices/clickhouse/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Audited actions, the same as actions of admin pages
+const (
+	auditCreateUser   = "users/create"
+	auditDisableUser  = "users/disable"
+	auditEnableUser   = "users/enable"
+	auditSetRole      = "users/role"
+	auditSetPassword  = "users/passwd"
+	auditKillSessions = "users/logout"
+	auditKillSession  = "sessions/kill"
+	auditPurge        = "messages/purge"
+)
+
+func dialAudit() (*conn, error) {
+	return dial("clickhouse", config.Config.ClickhouseAdapter.URL, config.Config.ClickhouseAdapter.TokenAuth, metadata.Pairs(
+		"dbname", config.Config.ClickhouseAdapter.DbName,
+		"tablename", config.Config.ClickhouseAdapter.AuditTableName,
+	))
+}
+
+// Records event of the command, outcome follows from err of the command
+func recordAudit(ctx context.Context, action, target, details string, err error) {
+	e := &clickhouseconnector.AuditEvent{
+		Actor:   "chatctl:" + systemUser(),
+		Action:  action,
+		Target:  target,
+		Time:    time.Now().Format("2006-01-02 15:04:05"),
+		Outcome: auditOutcome(err),
+		Details: details,
+	}
+	if err != nil && e.Details == "" {
+		e.Details = status.Convert(err).Message()
+	}
+	c, dialErr := dialAudit()
+	if dialErr == nil {
+		defer c.cc.Close()
+		_, dialErr = clickhouseconnector.NewAuditClient(c.cc).Record(c.ctx(ctx), &clickhouseconnector.RecordRequest{Event: e})
+	}
+	if dialErr != nil {
+		fmt.Fprintln(os.Stderr, "chatctl: audit event is not stored:", describe(dialErr))
+	}
+}
+
+func auditOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case status.Code(err) == codes.PermissionDenied:
+		return "denied"
+	case errs.HTTPStatus(err) < http.StatusInternalServerError:
+		return "failure"
+	}
+	return "error"
+}
+
+// Name of the user, who runs chatctl
+func systemUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,282 @@
+// Admin command line tool: manages users, sessions, messages and logs through grpc of microservices
+// Connects the same way main does: TLS with client certificates of microservices and token from config
+// Usage: chatctl <users|sessions|messages|logs> <command> [flags], run from inside of chat_room_go
+
+package main
+
+import (
+	clickhouseconnector "chat_room_go/microservices/clickhouse/pb"
+	mongoconnector "chat_room_go/microservices/mongodb/pb"
+	redisconnector "chat_room_go/microservices/redis/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+const usage = `Usage: chatctl <group> <command> [flags] [args]
+
+  users list [-prefix p]
+  users create -login l -password p [-fname f] [-lname l] [-role user|admin]
+  users disable <login>         also kills sessions of the user
+  users enable <login>
+  users promote [-role admin] <login>
+  users passwd [-password p] <login>  also kills sessions of the user
+  sessions list [login]         shows prefixes of session ids
+  sessions kill [-session prefix] <login>
+  messages purge [-room r] [-author a] [-before date | -older duration] -yes
+  messages export [-room r]     json lines, all rooms if room is not set
+  logs flush                    writes cached logs to clickhouse
+
+Flags -timeout and -token go before the group. Password is taken from CHAT_PASSWORD or stdin, when flag is not set.
+`
+
+// Deadline of one command
+var timeout = flag.Duration("timeout", 30*time.Second, "Deadline of the command")
+
+// Token of microservices, overrides the ones from config
+var token = flag.String("token", "", "Token of microservices, default is the one of config")
+
+type command func(ctx context.Context, args []string) error
+
+var commands = map[string]map[string]command{
+	"users": {
+		"list":    usersList,
+		"create":  usersCreate,
+		"disable": usersDisable,
+		"enable":  usersEnable,
+		"promote": usersPromote,
+		"passwd":  usersPasswd,
+	},
+	"sessions": {
+		"list": sessionsList,
+		"kill": sessionsKill,
+	},
+	"messages": {
+		"purge":  messagesPurge,
+		"export": messagesExport,
+	},
+	"logs": {
+		"flush": logsFlush,
+	},
+}
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)][flag.Arg(1)]
+	if !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if err := cmd(ctx, flag.Args()[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "chatctl:", describe(err))
+		os.Exit(1)
+	}
+}
+
+// Error with reason of the microservice, when it has one
+func describe(err error) string {
+	if reason := errs.Reason(err); reason != "" {
+		return err.Error() + " (" + reason + ")"
+	}
+	return err.Error()
+}
+
+// Connection to microservice, metadata is attached to every call
+type conn struct {
+	cc *grpc.ClientConn
+	md metadata.MD
+}
+
+// Context of call with metadata of the connection
+func (c *conn) ctx(ctx context.Context) context.Context {
+	return metadata.NewOutgoingContext(ctx, c.md)
+}
+
+// Connects to microservice with certificates from microservices/<service>/certs
+func dial(service, url, serviceToken string, md metadata.MD) (*conn, error) {
+	creds, err := loadTLSCredentials(service)
+	if err != nil {
+		return nil, err
+	}
+	if *token != "" {
+		serviceToken = *token
+	}
+	cc, err := grpc.Dial(url,
+		grpc.WithPerRPCCredentials(&tokenAuth{serviceToken}),
+		grpc.WithTransportCredentials(creds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{cc: cc, md: md}, nil
+}
+
+func dialRedis() (*conn, error) {
+	return dial("redis", config.Config.RedisAdapter.URL, config.Config.RedisAdapter.TokenAuth, metadata.Pairs(
+		"expirationtime", strconv.Itoa(config.Config.SessionExpirationTime),
+	))
+}
+
+// Connects to mongodb microservice, messages collection by default
+func dialMongo(collection string) (*conn, error) {
+	return dial("mongodb", config.Config.MongoAdapter.URL, config.Config.MongoAdapter.TokenAuth, metadata.Pairs(
+		"dbname", config.Config.MongoAdapter.DbName,
+		"collectionname", collection,
+	))
+}
+
+func dialClickhouse() (*conn, error) {
+	return dial("clickhouse", config.Config.ClickhouseAdapter.URL, config.Config.ClickhouseAdapter.TokenAuth, metadata.Pairs(
+		"dbname", config.Config.ClickhouseAdapter.DbName,
+		"tablename", config.Config.ClickhouseAdapter.TableName,
+	))
+}
+
+func redisAdmin() (redisconnector.AdminClient, *conn, error) {
+	c, err := dialRedis()
+	if err != nil {
+		return nil, nil, err
+	}
+	return redisconnector.NewAdminClient(c.cc), c, nil
+}
+
+func mongoWriter() (mongoconnector.WriterClient, *conn, error) {
+	c, err := dialMongo(config.Config.MongoAdapter.CollectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mongoconnector.NewWriterClient(c.cc), c, nil
+}
+
+func clickhouseWriter() (clickhouseconnector.WriterClient, *conn, error) {
+	c, err := dialClickhouse()
+	if err != nil {
+		return nil, nil, err
+	}
+	return clickhouseconnector.NewWriterClient(c.cc), c, nil
+}
+
+// logs flush
+func logsFlush(ctx context.Context, args []string) error {
+	client, c, err := clickhouseWriter()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	res, err := client.Flush(c.ctx(ctx), &clickhouseconnector.FlushRequest{})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Flushed %d logs\n", res.Flushed)
+	return nil
+}
+
+// Parses flags of command, exactly n positional arguments are expected (any number if n < 0)
+func parseFlags(fs *flag.FlagSet, args []string, n int, names string) ([]string, error) {
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: chatctl %s [flags] %s\n", fs.Name(), names)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if n >= 0 && fs.NArg() != n {
+		fs.Usage()
+		return nil, fmt.Errorf("%s expects %d argument(s)", fs.Name(), n)
+	}
+	return fs.Args(), nil
+}
+
+// Returns password from flag, CHAT_PASSWORD or first line of stdin
+func readPassword(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if p := os.Getenv("CHAT_PASSWORD"); p != "" {
+		return p, nil
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	var line string
+	if _, err := fmt.Fscanln(os.Stdin, &line); err != nil {
+		return "", fmt.Errorf("password is not read: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// **********************************************
+// Below security logic, establishing tls, tokens
+// **********************************************
+type tokenAuth struct {
+	Token string
+}
+
+// Realization of PerRPCCredentials interface
+func (t *tokenAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": t.Token,
+	}, nil
+}
+
+// Realization of WithPerRPCCredentials interface
+func (c *tokenAuth) RequireTransportSecurity() bool {
+	return false
+}
+
+// Enables TLS and adds client certificates of the microservice
+func loadTLSCredentials(service string) (credentials.TransportCredentials, error) {
+	currentPath, err := filepath.Abs(".")
+	if err != nil {
+		return nil, err
+	}
+	certs := filepath.Join(strings.SplitAfterN(currentPath, "chat_room_go", 2)[0], "microservices", service, "certs")
+
+	// Load certificate of the CA who signed server's certificate
+	pemServerCA, err := ioutil.ReadFile(filepath.Join(certs, "ca-cert.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pemServerCA) {
+		return nil, fmt.Errorf("failed to add server CA's certificate")
+	}
+
+	// Load client's certificate and private key
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(certs, "client-cert.pem"), filepath.Join(certs, "client-key.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the credentials and return it
+	config := &tls.Config{
+		// Self signed certificate, TODO: Let`s Encrypt
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{clientCert},
+		RootCAs:            certPool,
+	}
+
+	return credentials.NewTLS(config), nil
+}
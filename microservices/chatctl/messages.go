@@ -0,0 +1,158 @@
+// Commands of messages group, they go to mongodb microservice
+
+package main
+
+import (
+	mongoconnector "chat_room_go/microservices/mongodb/pb"
+	config "chat_room_go/utils/conf"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Messages of one Read call of export
+const exportPage = 500
+
+// Formats of -before flag of purge, local time
+var beforeFormats = []string{"2006-01-02 15:04:05", "2006-01-02"}
+
+// Message as export writes it, one json per line
+type exportedMessage struct {
+	ID     string `json:"id"`
+	Room   string `json:"room"`
+	Author string `json:"author"`
+	Nick   string `json:"nick,omitempty"`
+	Text   string `json:"text"`
+	Time   string `json:"time"`
+	Bot    bool   `json:"bot,omitempty"`
+	Edited string `json:"edited,omitempty"`
+}
+
+// messages purge
+func messagesPurge(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("messages purge", flag.ContinueOnError)
+	room := fs.String("room", "", "Room of messages, all rooms if empty")
+	author := fs.String("author", "", "Author of messages, any if empty")
+	before := fs.String("before", "", "Messages written before the date, \"2006-01-02\" or \"2006-01-02 15:04:05\"")
+	older := fs.Duration("older", 0, "Messages older than duration, e.g. 720h")
+	yes := fs.Bool("yes", false, "Confirms deletion, messages can not be restored")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	req := &mongoconnector.PurgeRequest{Room: *room, Name: *author}
+	if *before != "" && *older != 0 {
+		return fmt.Errorf("-before and -older can not be used together")
+	}
+	if *before != "" {
+		t, err := parseBefore(*before)
+		if err != nil {
+			return err
+		}
+		req.Before = t.Unix()
+	}
+	if *older != 0 {
+		req.Before = time.Now().Add(-*older).Unix()
+	}
+	if req.Room == "" && req.Name == "" && req.Before == 0 {
+		return fmt.Errorf("set -room, -author, -before or -older")
+	}
+	if !*yes {
+		return fmt.Errorf("purge deletes messages for good, repeat with -yes")
+	}
+
+	client, c, err := mongoWriter()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	res, err := client.Purge(c.ctx(ctx), req)
+	recordAudit(ctx, auditPurge, req.Room, purgeDetails(req, res), err)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d messages\n", res.Deleted)
+	return nil
+}
+
+// Filters and number of deleted messages for audit
+func purgeDetails(req *mongoconnector.PurgeRequest, res *mongoconnector.PurgeResponse) string {
+	details := fmt.Sprintf("author=%s before=%d", req.Name, req.Before)
+	if res != nil {
+		details += fmt.Sprintf(" deleted=%d", res.Deleted)
+	}
+	return details
+}
+
+func parseBefore(value string) (time.Time, error) {
+	for _, layout := range beforeFormats {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("-before is not a date: %q", value)
+}
+
+// messages export, rooms go one after another, messages of a room in chronological order
+func messagesExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("messages export", flag.ContinueOnError)
+	room := fs.String("room", "", "Room to export, all rooms if empty")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+
+	rooms := []string{*room}
+	if *room == "" {
+		rc, err := dialMongo(config.Config.MongoAdapter.RoomsCollectionName)
+		if err != nil {
+			return err
+		}
+		defer rc.cc.Close()
+		res, err := mongoconnector.NewRoomsClient(rc.cc).ListRooms(rc.ctx(ctx), &mongoconnector.ListRoomsRequest{})
+		if err != nil {
+			return err
+		}
+		rooms = rooms[:0]
+		for _, r := range res.Results {
+			rooms = append(rooms, r.Name)
+		}
+	}
+
+	c, err := dialMongo(config.Config.MongoAdapter.CollectionName)
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+	reader := mongoconnector.NewReaderClient(c.cc)
+	enc := json.NewEncoder(os.Stdout)
+	total := 0
+	for _, r := range rooms {
+		// Read pages back from the newest message
+		var messages []*mongoconnector.MessageInfo
+		before := ""
+		for {
+			res, err := reader.Read(c.ctx(ctx), &mongoconnector.ReadRequest{Room: r, Before: before, Number: exportPage})
+			if err != nil {
+				return err
+			}
+			messages = append(res.Results, messages...)
+			if !res.HasMore || len(res.Results) == 0 {
+				break
+			}
+			before = res.Results[0].Id
+		}
+		for _, m := range messages {
+			err := enc.Encode(exportedMessage{ID: m.Id, Room: m.Room, Author: m.Name, Nick: m.Nick, Text: m.Message, Time: m.Time, Bot: m.Bot, Edited: m.Edited})
+			if err != nil {
+				return err
+			}
+		}
+		total += len(messages)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d messages of %d rooms\n", total, len(rooms))
+	return nil
+}
@@ -0,0 +1,291 @@
+// Commands of users and sessions groups, they go to redis microservice
+
+package main
+
+import (
+	redisconnector "chat_room_go/microservices/redis/pb"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// users list
+func usersList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("users list", flag.ContinueOnError)
+	prefix := fs.String("prefix", "", "Only logins starting with prefix")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+	client, c, err := redisAdmin()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	res, err := client.ListUsers(c.ctx(ctx), &redisconnector.ListUsersRequest{Prefix: *prefix})
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOGIN\tNAME\tNICK\tROLE\tSTATUS")
+	for _, u := range res.Results {
+		status := "active"
+		if u.Disabled {
+			status = "disabled"
+		}
+		fmt.Fprintf(tw, "%s\t%s %s\t%s\t%s\t%s\n", u.Login, u.Fname, u.Lname, u.Nick, u.Role, status)
+	}
+	return tw.Flush()
+}
+
+// users create, user is written as signup page writes it, but no signup event is published
+func usersCreate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("users create", flag.ContinueOnError)
+	login := fs.String("login", "", "Login of new user")
+	password := fs.String("password", "", "Password, default is CHAT_PASSWORD or stdin")
+	fname := fs.String("fname", "", "First name")
+	lname := fs.String("lname", "", "Last name")
+	role := fs.String("role", "user", "Role: user or admin")
+	if _, err := parseFlags(fs, args, 0, ""); err != nil {
+		return err
+	}
+	if *login == "" {
+		return fmt.Errorf("-login is required")
+	}
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+	hash, err := hashPassword(pass)
+	if err != nil {
+		return err
+	}
+
+	client, c, err := redisAdmin()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+	_, err = client.CreateUser(c.ctx(ctx), &redisconnector.WriteRequest{
+		Login:      *login,
+		Fname:      *fname,
+		Lname:      *lname,
+		Pass:       string(hash),
+		Role:       *role,
+		LastActive: time.Now().Format("2006-01-02 15:04:05"),
+	})
+	recordAudit(ctx, auditCreateUser, *login, "role="+*role, err)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Created", *login)
+	return nil
+}
+
+// users disable
+func usersDisable(ctx context.Context, args []string) error {
+	return setDisabled(ctx, "users disable", args, true)
+}
+
+// users enable
+func usersEnable(ctx context.Context, args []string) error {
+	return setDisabled(ctx, "users enable", args, false)
+}
+
+func setDisabled(ctx context.Context, name string, args []string, disabled bool) error {
+	action := auditEnableUser
+	if disabled {
+		action = auditDisableUser
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	rest, err := parseFlags(fs, args, 1, "<login>")
+	if err != nil {
+		return err
+	}
+	client, c, err := redisAdmin()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	_, err = client.SetDisabled(c.ctx(ctx), &redisconnector.SetDisabledRequest{Login: rest[0], Disabled: disabled})
+	recordAudit(ctx, action, rest[0], "", err)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		fmt.Println("Disabled", rest[0])
+	} else {
+		fmt.Println("Enabled", rest[0])
+	}
+	return nil
+}
+
+// users promote
+func usersPromote(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("users promote", flag.ContinueOnError)
+	role := fs.String("role", "admin", "New role of the user")
+	rest, err := parseFlags(fs, args, 1, "<login>")
+	if err != nil {
+		return err
+	}
+	client, c, err := redisAdmin()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	_, err = client.SetRole(c.ctx(ctx), &redisconnector.SetRoleRequest{Login: rest[0], Role: *role})
+	recordAudit(ctx, auditSetRole, rest[0], "role="+*role, err)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s is %s now\n", rest[0], *role)
+	return nil
+}
+
+// Session ids are credentials, list shows only prefix of this length, kill accepts it
+const sessionPrefixLen = 8
+
+// users passwd, sessions of the user are killed, so the old password does not keep anyone logged in
+func usersPasswd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("users passwd", flag.ContinueOnError)
+	password := fs.String("password", "", "New password, default is CHAT_PASSWORD or stdin")
+	rest, err := parseFlags(fs, args, 1, "<login>")
+	if err != nil {
+		return err
+	}
+	pass, err := readPassword(*password)
+	if err != nil {
+		return err
+	}
+	hash, err := hashPassword(pass)
+	if err != nil {
+		return err
+	}
+	client, c, err := redisAdmin()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	_, err = client.SetPassword(c.ctx(ctx), &redisconnector.SetPasswordRequest{Login: rest[0], Pass: string(hash)})
+	recordAudit(ctx, auditSetPassword, rest[0], "", err)
+	if err != nil {
+		return err
+	}
+	res, err := client.KillSessions(c.ctx(ctx), &redisconnector.KillSessionsRequest{Login: rest[0]})
+	recordAudit(ctx, auditKillSessions, rest[0], "", err)
+	if err != nil {
+		return fmt.Errorf("password of %s is changed, but sessions are not killed: %w", rest[0], err)
+	}
+	fmt.Printf("Password of %s is changed, killed %d sessions\n", rest[0], res.Killed)
+	return nil
+}
+
+// sessions list
+func sessionsList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("sessions list", flag.ContinueOnError)
+	rest, err := parseFlags(fs, args, -1, "[login]")
+	if err != nil {
+		return err
+	}
+	login := ""
+	if len(rest) > 0 {
+		login = rest[0]
+	}
+	client, c, err := redisAdmin()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	res, err := client.ListSessions(c.ctx(ctx), &redisconnector.ListSessionsRequest{Login: login})
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOGIN\tSESSION\tEXPIRES IN")
+	for _, s := range res.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Login, sessionPrefix(s.Id), time.Duration(s.TTL)*time.Second)
+	}
+	return tw.Flush()
+}
+
+// Returns shown part of session id
+func sessionPrefix(id string) string {
+	if len(id) <= sessionPrefixLen {
+		return id
+	}
+	return id[:sessionPrefixLen] + "..."
+}
+
+// sessions kill, all sessions of the user or one by prefix from sessions list
+func sessionsKill(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("sessions kill", flag.ContinueOnError)
+	prefix := fs.String("session", "", "Only the session with id starting with prefix from sessions list")
+	rest, err := parseFlags(fs, args, 1, "<login>")
+	if err != nil {
+		return err
+	}
+	client, c, err := redisAdmin()
+	if err != nil {
+		return err
+	}
+	defer c.cc.Close()
+
+	if *prefix != "" {
+		return killSession(ctx, client, c, rest[0], strings.TrimSuffix(*prefix, "..."))
+	}
+	res, err := client.KillSessions(c.ctx(ctx), &redisconnector.KillSessionsRequest{Login: rest[0]})
+	recordAudit(ctx, auditKillSessions, rest[0], "", err)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Killed %d sessions of %s\n", res.Killed, rest[0])
+	return nil
+}
+
+// Kills the only session of the user, that starts with prefix
+func killSession(ctx context.Context, client redisconnector.AdminClient, c *conn, login, prefix string) error {
+	if len(prefix) < sessionPrefixLen {
+		return fmt.Errorf("session prefix must have at least %d characters", sessionPrefixLen)
+	}
+	res, err := client.ListSessions(c.ctx(ctx), &redisconnector.ListSessionsRequest{Login: login})
+	if err != nil {
+		return err
+	}
+	var ids []string
+	for _, s := range res.Results {
+		if strings.HasPrefix(s.Id, prefix) {
+			ids = append(ids, s.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("%s has no session %s", login, prefix)
+	} else if len(ids) > 1 {
+		return fmt.Errorf("%s has %d sessions starting with %s, give a longer prefix", login, len(ids), prefix)
+	}
+
+	_, err = redisconnector.NewWriterSessionClient(c.cc).DeleteSession(c.ctx(ctx), &redisconnector.DeleteSessionRequest{SessionId: ids[0]})
+	recordAudit(ctx, auditKillSession, login, "session="+prefix, err)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Killed session %s of %s\n", prefix, login)
+	return nil
+}
+
+// Returns bcrypt hash of password, as main stores it
+func hashPassword(password string) ([]byte, error) {
+	if password == "" {
+		return nil, fmt.Errorf("password is empty")
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+}
@@ -0,0 +1,244 @@
+// Implements Audit service: security and admin events of main in their own table
+// Events are written right away, not through the cache of logs, and table has no update or delete
+// Table is MergeTree, so unlike logs the events survive restart of clickhouse
+// Audit keeps one connection pool, database and table are created once: at start or by the first request to them
+
+package clickhouseservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/clickhouse/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Number of events returned, when request does not limit it
+const defaultAuditNumber = 100
+
+// Max number of events of one request
+const maxAuditNumber = 1000
+
+type RPCAudit struct{}
+
+// Connection pool of audit and tables, that are known to exist
+var audit = struct {
+	sync.Mutex
+	db     *sqlx.DB
+	tables map[string]bool
+}{tables: make(map[string]bool)}
+
+// Audit event as stored in clickhouse
+type auditRow struct {
+	Actor      string    `db:"actor"`
+	Action     string    `db:"action"`
+	Target     string    `db:"target"`
+	IP         string    `db:"ip"`
+	Outcome    string    `db:"outcome"`
+	Details    string    `db:"details"`
+	RequestID  string    `db:"request_id"`
+	ActionTime time.Time `db:"action_time"`
+}
+
+func (r *auditRow) event() *grpcconnector.AuditEvent {
+	return &grpcconnector.AuditEvent{
+		Actor:     r.Actor,
+		Action:    r.Action,
+		Target:    r.Target,
+		Ip:        r.IP,
+		Time:      r.ActionTime.Format("2006-01-02 15:04:05"),
+		Outcome:   r.Outcome,
+		Details:   r.Details,
+		RequestId: r.RequestID,
+	}
+}
+
+// grpc Record implementation
+func (a RPCAudit) Record(ctx context.Context, i *grpcconnector.RecordRequest) (*grpcconnector.RecordResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	tableName, err := mmw.RequiredMetadata(ctx, "tablename")
+	if err != nil {
+		return nil, err
+	}
+	e := i.GetEvent()
+	switch {
+	case e.GetAction() == "":
+		return nil, errs.Invalid("event.action", "action is not supplied")
+	case e.GetOutcome() == "":
+		return nil, errs.Invalid("event.outcome", "outcome is not supplied")
+	}
+	row := auditRow{Actor: e.Actor, Action: e.Action, Target: e.Target, IP: e.Ip, Outcome: e.Outcome, Details: e.Details, RequestID: e.RequestId, ActionTime: time.Now()}
+	if e.Time != "" {
+		if row.ActionTime, err = time.ParseInLocation("2006-01-02 15:04:05", e.Time, time.Local); err != nil {
+			return nil, errs.Invalid("event.time", "time must be \"2006-01-02 15:04:05\"")
+		}
+	}
+
+	if err := writeAuditToDB(ctx, dbName, tableName, &row); err != nil {
+		mmw.LogError(log, "Error during audit insertion", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.RecordResponse{}, nil
+}
+
+// grpc ListAudit implementation
+func (a RPCAudit) ListAudit(ctx context.Context, i *grpcconnector.ListAuditRequest) (*grpcconnector.ListAuditResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	tableName, err := mmw.RequiredMetadata(ctx, "tablename")
+	if err != nil {
+		return nil, err
+	}
+	limit := int(i.Limit)
+	if limit <= 0 {
+		limit = defaultAuditNumber
+	}
+	if limit > maxAuditNumber {
+		limit = maxAuditNumber
+	}
+
+	toReturn, err := auditFromDB(ctx, dbName, tableName, limit, i)
+	if err != nil {
+		mmw.LogError(log, "Error during audit reading", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.ListAuditResponse{Results: toReturn}, nil
+}
+
+// Creates audit table if not already exists
+func createAuditTable(ctx context.Context, dbName string, tableName string, connect *sqlx.DB) error {
+	_, err := connect.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS `+dbName+`.`+tableName+` (
+		actor        String,
+		action       String,
+		target       String,
+		ip           String,
+		outcome      String,
+		details      String,
+		request_id   String,
+		action_time  DateTime
+	) engine=MergeTree ORDER BY action_time
+    `)
+	if err != nil {
+		return errs.Wrap(errs.Internal, errs.ReasonDatabaseError, fmt.Sprintf("Error during table creation \"%s.%s\"", dbName, tableName), err)
+	}
+	logger.Infof("Success! Table \"%s.%s\" created or already exists", dbName, tableName)
+	return nil
+}
+
+// Creates database and audit table of config, so requests find them ready
+func PrepareAudit(ctx context.Context) error {
+	_, err := prepareAuditDB(ctx, config.Config.ClickhouseAdapter.DbName, config.Config.ClickhouseAdapter.AuditTableName)
+	return err
+}
+
+// Returns connection pool of audit, database and audit table are created, if they were not yet
+func prepareAuditDB(ctx context.Context, dbName, tableName string) (*sqlx.DB, error) {
+	audit.Lock()
+	defer audit.Unlock()
+	if audit.db == nil {
+		db, err := sqlx.Open("clickhouse", dbURL)
+		if err != nil {
+			return nil, errs.Wrap(errs.Unavailable, errs.ReasonDatabaseUnavailable, "Error during connecting to database", err)
+		}
+		audit.db = db
+	}
+	if audit.tables[dbName+"."+tableName] {
+		return audit.db, nil
+	}
+	if _, err := audit.db.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+dbName); err != nil {
+		return nil, errs.Wrap(errs.Internal, errs.ReasonDatabaseError, fmt.Sprintf("Error during database creation \"%s\"", dbName), err)
+	}
+	if err := createAuditTable(ctx, dbName, tableName, audit.db); err != nil {
+		return nil, err
+	}
+	audit.tables[dbName+"."+tableName] = true
+	return audit.db, nil
+}
+
+// Closes connection pool of audit
+func closeAudit() error {
+	audit.Lock()
+	defer audit.Unlock()
+	if audit.db == nil {
+		return nil
+	}
+	err := audit.db.Close()
+	audit.db = nil
+	audit.tables = make(map[string]bool)
+	return err
+}
+
+// Writes one event to db
+func writeAuditToDB(ctx context.Context, dbName, tableName string, row *auditRow) error {
+	defer mmw.ObserveDB("clickhouse", "insert_audit", time.Now())
+	conn, err := prepareAuditDB(ctx, dbName, tableName)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	statement, err := tx.PrepareContext(ctx, "INSERT INTO "+dbName+"."+tableName+" (actor, action, target, ip, outcome, details, request_id, action_time) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer statement.Close()
+
+	if _, err := statement.ExecContext(ctx, row.Actor, row.Action, row.Target, row.IP, row.Outcome, row.Details, row.RequestID, row.ActionTime); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Reads last events from clickhouse, newest first
+func auditFromDB(ctx context.Context, dbName, tableName string, limit int, filter *grpcconnector.ListAuditRequest) ([]*grpcconnector.AuditEvent, error) {
+	defer mmw.ObserveDB("clickhouse", "select_audit", time.Now())
+	conn, err := prepareAuditDB(ctx, dbName, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT actor, action, target, ip, outcome, details, request_id, action_time FROM " + dbName + "." + tableName + " WHERE 1 = 1"
+	args := []interface{}{}
+	for column, value := range map[string]string{"actor": filter.Actor, "action": filter.Action, "target": filter.Target} {
+		if value != "" {
+			query += " AND " + column + " = ?"
+			args = append(args, value)
+		}
+	}
+	query += " ORDER BY action_time DESC LIMIT ?"
+	args = append(args, limit)
+
+	var rows []auditRow
+	if err := conn.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	toReturn := make([]*grpcconnector.AuditEvent, len(rows))
+	for n := range rows {
+		toReturn[n] = rows[n].event()
+	}
+	return toReturn, nil
+}
@@ -2,61 +2,76 @@
 package main
 
 import (
-	grpcconnector "chat_room_go/microservices/clickhouse/pb"
+	clickhouseservice "chat_room_go/microservices/clickhouse"
 	config "chat_room_go/utils/conf"
 	"chat_room_go/utils/logs"
+	"chat_room_go/utils/tracing"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net"
+	"time"
 
 	mmw "chat_room_go/microservices"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
-var logger *zap.SugaredLogger
-
-func init() {
-	logger = logs.InitDirLogger(config.Config.ClickhouseAdapter.PathToLogs)
-}
-
 func main() {
-	defer logger.Sync()
 	creds, err := loadTLSCredentials()
 	if err != nil {
 		log.Fatal("cannot load TLS credentials: ", err)
 	}
+	shutdownTracing, err := tracing.Init("chat-clickhouse")
+	if err != nil {
+		log.Fatal("cannot init tracing: ", err)
+	}
 	mmw.TokenAuth = config.Config.ClickhouseAdapter.TokenAuth
 	server := grpc.NewServer(
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(mmw.LogInterceptor, mmw.AuthInterceptor)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(mmw.TracingInterceptor, mmw.LogInterceptor, mmw.MetricsInterceptor, mmw.AuthInterceptor, mmw.ErrorInterceptor)),
 		grpc.InTapHandle(mmw.RateLimiter),
 	)
-	grpcconnector.RegisterWriterServer(server, RPCWriter{})
+	// Background work of the service: health checks
+	background, stopBackground := context.WithCancel(context.Background())
+	clickhouseservice.Register(background, server)
+	prepareCtx, cancelPrepare := context.WithTimeout(context.Background(), time.Minute)
+	if err := clickhouseservice.PrepareAudit(prepareCtx); err != nil {
+		log.Println("audit table is not created: ", err)
+	}
+	cancelPrepare()
 
 	lis, err := net.Listen("tcp", config.Config.ClickhouseAdapter.IntURL)
 	if err != nil {
 		log.Fatalln("cant listen port", err)
 	}
-	defer lis.Close()
-	defer func() {
-		err := WriteCache()
-		if err != nil {
-			logger.Panicf("%v", err)
+
+	fmt.Println("starting server at ", config.Config.ClickhouseAdapter.IntURL)
+	metricsServer := mmw.ServeMetrics(config.Config.ClickhouseAdapter.MetricsURL)
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			log.Println("server stopped: ", err)
 		}
 	}()
 
-	fmt.Println("starting server at ", config.Config.ClickhouseAdapter.IntURL)
-	logger.Debugf("Recieved request",
-		"method", "hello",
-	)
-	server.Serve(lis)
+	sig := mmw.WaitForSignal()
+	fmt.Println("received ", sig, ", shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), config.Config.ShutdownDuration())
+	defer cancel()
+	metricsServer.Shutdown(ctx)
+	defer shutdownTracing(ctx)
+	// Health turns NOT_SERVING, so probes stop sending requests
+	stopBackground()
+	mmw.GracefulStop(ctx, server)
+	if err := clickhouseservice.Close(); err != nil {
+		log.Println("error during cleanup: ", err)
+	}
+	logs.Logger.Sync()
 }
 
 func loadTLSCredentials() (credentials.TransportCredentials, error) {
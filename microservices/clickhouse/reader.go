@@ -0,0 +1,109 @@
+// Implements Recent function of Reader service: last logs for admin pages
+// Logs, that are still in cache, go first, the rest is read from clickhouse
+
+package clickhouseservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/clickhouse/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"strings"
+	"time"
+)
+
+// Number of logs returned, when request does not limit it
+const defaultRecentNumber = 100
+
+// Max number of logs of one request
+const maxRecentNumber = 1000
+
+type RPCReader struct{}
+
+// grpc Recent implementation
+func (w RPCReader) Recent(ctx context.Context, i *grpcconnector.RecentRequest) (*grpcconnector.RecentResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	tableName, err := mmw.RequiredMetadata(ctx, "tablename")
+	if err != nil {
+		return nil, err
+	}
+	limit := int(i.Limit)
+	if limit <= 0 {
+		limit = defaultRecentNumber
+	}
+	if limit > maxRecentNumber {
+		limit = maxRecentNumber
+	}
+
+	toReturn := recentFromCache(limit, i.Contains)
+	if len(toReturn) < limit {
+		stored, err := recentFromDB(ctx, dbName, tableName, limit-len(toReturn), i.Contains)
+		if err != nil {
+			mmw.LogError(log, "Error during table reading", err)
+			return nil, errs.Database(err)
+		}
+		toReturn = append(toReturn, stored...)
+	}
+
+	return &grpcconnector.RecentResponse{Results: toReturn}, nil
+}
+
+// Returns cached logs from the newest one
+func recentFromCache(limit int, contains string) []*grpcconnector.LogEntry {
+	cache.m.RLock()
+	defer cache.m.RUnlock()
+
+	var toReturn []*grpcconnector.LogEntry
+	for n := len(cache.v) - 1; n >= 0 && len(toReturn) < limit; n-- {
+		if strings.Contains(cache.v[n].Log, contains) {
+			toReturn = append(toReturn, entry(cache.v[n]))
+		}
+	}
+	return toReturn
+}
+
+// Reads last logs from clickhouse, newest first
+func recentFromDB(ctx context.Context, dbName, tableName string, limit int, contains string) ([]*grpcconnector.LogEntry, error) {
+	defer mmw.ObserveDB("clickhouse", "select", time.Now())
+	conn, err := prepareDB()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// Table appears with the first written cache, until then there is nothing to read
+	if err := createDB(dbName, conn); err != nil {
+		return nil, err
+	}
+	if err := createTable(dbName, tableName, conn); err != nil {
+		return nil, err
+	}
+
+	var items []item
+	query := "SELECT log, action_time FROM " + dbName + "." + tableName
+	args := []interface{}{}
+	if contains != "" {
+		query += " WHERE position(log, ?) > 0"
+		args = append(args, contains)
+	}
+	query += " ORDER BY action_time DESC LIMIT ?"
+	args = append(args, limit)
+	if err := conn.SelectContext(ctx, &items, query, args...); err != nil {
+		return nil, err
+	}
+
+	toReturn := make([]*grpcconnector.LogEntry, len(items))
+	for n := range items {
+		toReturn[n] = entry(items[n])
+	}
+	return toReturn, nil
+}
+
+func entry(i item) *grpcconnector.LogEntry {
+	return &grpcconnector.LogEntry{Log: i.Log, Time: i.ActionTime.Format("2006-01-02 15:04:05")}
+}
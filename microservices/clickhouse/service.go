@@ -0,0 +1,78 @@
+// Clickhouse microservice as a library, grpc services are registered by the caller
+// Used by the standalone server (cmd) and by all-in-one mode of main
+
+package clickhouseservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/clickhouse/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/logs"
+	"context"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+var logger *zap.SugaredLogger
+
+// Gauges are registered on the first Register only, registry panics on duplicates
+var registerGauges sync.Once
+
+func init() {
+	logger = logs.InitDirLogger(config.Config.ClickhouseAdapter.PathToLogs)
+}
+
+// Registers all grpc services of the microservice, including health service, that pings database until ctx is done
+func Register(ctx context.Context, server *grpc.Server) {
+	mmw.RegisterHealth(ctx, server, Ping)
+	registerGauges.Do(func() {
+		prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "chat_clickhouse_cache_items",
+			Help: "Number of logs waiting in cache to be written to clickhouse",
+		}, cacheFill))
+	})
+	grpcconnector.RegisterWriterServer(server, RPCWriter{})
+	grpcconnector.RegisterReaderServer(server, RPCReader{})
+	grpcconnector.RegisterAuditServer(server, RPCAudit{})
+}
+
+// Checks that clickhouse is reachable
+func Ping(ctx context.Context) error {
+	conn, err := sqlx.Open("clickhouse", dbURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.PingContext(ctx)
+}
+
+// Returns current number of items in cache
+func cacheFill() float64 {
+	cache.m.RLock()
+	defer cache.m.RUnlock()
+
+	return float64(len(cache.v))
+}
+
+// Flushes log cache to clickhouse, closes connection pool of audit, flushes logger of the microservice
+func Close() error {
+	cache.m.RLock()
+	empty := len(cache.v) == 0
+	cache.m.RUnlock()
+
+	var err error
+	if !empty {
+		err = WriteCache()
+	}
+	if closeErr := closeAudit(); err == nil {
+		err = closeErr
+	}
+	logger.Sync()
+
+	return err
+}
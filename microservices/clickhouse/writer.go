@@ -3,9 +3,12 @@
 // Every log goes by grpc to this microservice and comes to clickhouse
 // Every cache write we establish new connection to database. TODO: remove this feature
 
-package main
+package clickhouseservice
 
 import (
+	mmw "chat_room_go/microservices"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
 	"context"
 	"fmt"
 	"sync"
@@ -16,9 +19,6 @@ import (
 
 	"github.com/ClickHouse/clickhouse-go"
 	"github.com/jmoiron/sqlx"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 var dbURL string = config.Config.ClickhouseAdapter.DbName
@@ -49,36 +49,68 @@ type RPCWriter struct{}
 
 // grpc Write implementation
 func (w RPCWriter) Write(ctx context.Context, i *grpcconnector.WriteRequest) (*grpcconnector.WriteResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "Metadata was not found"}, status.Errorf(codes.NotFound, "Metadata was not found")
-	}
-	dbNames, ok := md["dbname"]
-	if !ok || len(dbNames) != 1 {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "dbName is not supplied"}, status.Errorf(codes.NotFound, "dbName is not supplied")
+	log := logs.With(ctx, logger)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
 	}
-	dbName := dbNames[0]
-	tableNames, ok := md["tablename"]
-	if !ok || len(tableNames) != 1 {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "tableName is not supplied"}, status.Errorf(codes.NotFound, "tableName is not supplied")
+	tableName, err := mmw.RequiredMetadata(ctx, "tablename")
+	if err != nil {
+		return nil, err
 	}
-	tableName := tableNames[0]
-	err := writeToDB(dbName, tableName, i.Log)
+	err = writeToDB(dbName, tableName, i.Log)
 	if err != nil {
-		logger.Errorf("Error during table insertion \"%s\"", err)
-		return &grpcconnector.WriteResponse{Status: 500, Desription: "Error during table insertion"}, status.Errorf(codes.NotFound, "Error during table insertion: %s", err)
+		mmw.LogError(log, "Error during table insertion", err)
+		return nil, errs.Database(err)
 	}
 
 	fmt.Println("Request: ", i.Log)
 
-	return &grpcconnector.WriteResponse{Status: 0, Desription: "Ok"}, nil
+	return &grpcconnector.WriteResponse{}, nil
+}
+
+// grpc Flush implementation, writes cached logs without waiting for cache to fill
+func (w RPCWriter) Flush(ctx context.Context, i *grpcconnector.FlushRequest) (*grpcconnector.FlushResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	flushed, err := flushCache()
+	if err != nil {
+		mmw.LogError(log, "Error during cache flushing", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.FlushResponse{Flushed: int32(flushed)}, nil
+}
+
+// Writes cache to database and drops written items, returns their number
+func flushCache() (int, error) {
+	cache.m.RLock()
+	n := len(cache.v)
+	cache.m.RUnlock()
+	if n == 0 {
+		return 0, nil
+	}
+
+	if err := WriteCache(); err != nil {
+		return 0, err
+	}
+	// Logs written meanwhile stay in cache
+	cache.m.Lock()
+	rest := make([]item, 0, cacheSize)
+	if len(cache.v) > n {
+		rest = append(rest, cache.v[n:]...)
+	}
+	cache.v = rest
+	cache.m.Unlock()
+
+	return n, nil
 }
 
 // Creates database if not already exists
 func createDB(dbName string, connect *sqlx.DB) error {
 	_, err := connect.Exec("CREATE DATABASE IF NOT EXISTS " + dbName)
 	if err != nil {
-		return status.Errorf(codes.Internal, "Error during database creation \"%s\"", dbName)
+		return errs.Wrap(errs.Internal, errs.ReasonDatabaseError, fmt.Sprintf("Error during database creation \"%s\"", dbName), err)
 	}
 	logger.Infof("Success! Database \"%s\" created or already exists", dbName)
 	return nil
@@ -93,7 +125,7 @@ func createTable(dbName string, tableName string, connect *sqlx.DB) error {
 	) engine=Memory
     `)
 	if err != nil {
-		return status.Errorf(codes.Internal, "Error during table creation \"%s.%s\"", dbName, tableName)
+		return errs.Wrap(errs.Internal, errs.ReasonDatabaseError, fmt.Sprintf("Error during table creation \"%s.%s\"", dbName, tableName), err)
 	}
 	logger.Infof("Success! Table \"%s.%s\" created or already exists", dbName, tableName)
 	return nil
@@ -118,7 +150,7 @@ func writeToDB(dbName string, tableName string, log string) error {
 		cache.m.Unlock()
 	} else {
 		cache.m.RUnlock()
-		return status.Errorf(codes.Internal, "Cache overflowed")
+		return errs.New(errs.Internal, errs.ReasonInternal, "Cache overflowed")
 	}
 
 	return nil
@@ -126,11 +158,12 @@ func writeToDB(dbName string, tableName string, log string) error {
 
 // Writes cache to database, should defer in main
 func WriteCache() error {
+	defer mmw.ObserveDB("clickhouse", "write_cache", time.Now())
 	cache.m.RLock()
 	defer cache.m.RUnlock()
 
 	if len(cache.v) <= 0 {
-		return status.Errorf(codes.Internal, "Cache error")
+		return errs.New(errs.Internal, errs.ReasonInternal, "Cache error")
 	}
 
 	// TODO: work with multiple databases
@@ -139,15 +172,15 @@ func WriteCache() error {
 
 	conn, err := prepareDB()
 	if err != nil {
-		return status.Errorf(codes.NotFound, "Error during initialising db connection: %s", err)
+		return errs.Wrap(errs.Unavailable, errs.ReasonDatabaseUnavailable, "Error during initialising db connection", err)
 	}
 	err = createDB(dbName, conn)
 	if err != nil {
-		return status.Errorf(codes.Internal, "Error during db creation: %s", err)
+		return err
 	}
 	err = createTable(dbName, tableName, conn)
 	if err != nil {
-		return status.Errorf(codes.Internal, "Error during table creation: %s", err)
+		return err
 	}
 	var (
 		tx, _        = conn.Begin()
@@ -160,12 +193,12 @@ func WriteCache() error {
 			v.Log,
 			v.ActionTime,
 		); err != nil {
-			return status.Errorf(codes.Internal, "Error during database insertion \"%s.%s\": %s", dbName, tableName, err)
+			return errs.Database(err)
 		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return status.Errorf(codes.Internal, "Error during database insertion \"%s.%s\": %s", dbName, tableName, err)
+		return errs.Database(err)
 	}
 
 	return nil
@@ -199,7 +232,8 @@ func getConnectionToDB(rc chan<- *sqlx.DB, ec chan<- error, wg *sync.WaitGroup)
 	connect, err := sqlx.Open("clickhouse", dbURL)
 	if err != nil {
 		wg.Done()
-		ec <- status.Errorf(codes.NotFound, "Error during connecting to database : Error = %s", err)
+		ec <- errs.Wrap(errs.Unavailable, errs.ReasonDatabaseUnavailable, "Error during connecting to database", err)
+		return
 	}
 
 	if err := connect.Ping(); err != nil {
@@ -209,7 +243,8 @@ func getConnectionToDB(rc chan<- *sqlx.DB, ec chan<- error, wg *sync.WaitGroup)
 			logger.Errorf("Unknown error during connecting to database : Error = %s", err)
 		}
 		wg.Done()
-		ec <- status.Errorf(codes.NotFound, "Error during connecting to database : Error = %s", err)
+		ec <- errs.Wrap(errs.Unavailable, errs.ReasonDatabaseUnavailable, "Error during connecting to database", err)
+		return
 	}
 	wg.Done()
 	rc <- connect
@@ -0,0 +1,132 @@
+// Events of write paths of microservices: messages created, edited, deleted and users signed up
+// Events are queued in redis, redis microservice delivers them to outgoing webhooks
+
+package events
+
+import (
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/logs"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Types of events
+const (
+	MessageCreated = "message.created"
+	MessageEdited  = "message.edited"
+	MessageDeleted = "message.deleted"
+	UserSignup     = "user.signup"
+)
+
+// All types of events, outgoing webhook subscribes to some of them
+var Types = []string{MessageCreated, MessageEdited, MessageDeleted, UserSignup}
+
+// Redis keys of delivery: queue of new events, events being fanned out, sorted set of deliveries by time, prefix of dead-letter lists
+const (
+	QueueKey      = "webhooks:queue"
+	ProcessingKey = "webhooks:processing"
+	RetryKey      = "webhooks:retry"
+	DeadKey       = "webhooks:dead"
+)
+
+// Dead-letter list of the hook owner, so failing hooks of one user do not push out letters of others
+func DeadLettersKey(login string) string {
+	return DeadKey + ":" + login
+}
+
+// Time to wait for redis when event is published, write itself is already done
+const publishTimeout = time.Second
+
+var published = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "chat_events_published_total",
+	Help: "Number of events queued for outgoing webhooks",
+}, []string{"type", "result"})
+
+// Event as it is queued and delivered
+type Event struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Time string          `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Data of message events
+type Message struct {
+	ID     string `json:"id"`
+	Room   string `json:"room"`
+	Author string `json:"author"`
+	Text   string `json:"text,omitempty"`
+	Time   string `json:"time"`
+	Bot    bool   `json:"bot,omitempty"`
+	Edited string `json:"edited,omitempty"`
+	// Id of the thread, if the message is a reply
+	Parent string `json:"parent,omitempty"`
+}
+
+// Data of user events, without password
+type User struct {
+	Login     string `json:"login"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// Connection pool for queue, events of mongodb microservice go to the same redis as of redis microservice
+var pool = &redis.Pool{
+	MaxIdle:     2,
+	IdleTimeout: 60 * time.Second,
+	// Publishing is synchronous in write paths, so stalled redis must not block them longer than publishTimeout
+	Dial: func() (redis.Conn, error) {
+		return redis.Dial("tcp", config.Config.RedisAdapter.DbURL,
+			redis.DialConnectTimeout(publishTimeout), redis.DialReadTimeout(publishTimeout), redis.DialWriteTimeout(publishTimeout))
+	},
+}
+
+// Returns random id of event or delivery
+func NewID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Queues event, errors are logged and counted, they never fail the write that caused the event
+func Publish(ctx context.Context, typ string, data interface{}) {
+	log := logs.Ctx(ctx)
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Error("Error during event encoding: ", err)
+		published.WithLabelValues(typ, "error").Inc()
+		return
+	}
+	event, err := json.Marshal(Event{ID: NewID(), Type: typ, Time: time.Now().Format(time.RFC3339), Data: raw})
+	if err != nil {
+		log.Error("Error during event encoding: ", err)
+		published.WithLabelValues(typ, "error").Inc()
+		return
+	}
+
+	pctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	conn, err := pool.GetContext(pctx)
+	if err == nil {
+		_, err = redis.DoWithTimeout(conn, publishTimeout, "LPUSH", QueueKey, event)
+		conn.Close()
+	}
+	if err != nil {
+		log.Errorf("Error during %s event publishing \"%s\"", typ, err)
+		published.WithLabelValues(typ, "error").Inc()
+		return
+	}
+	published.WithLabelValues(typ, "ok").Inc()
+}
+
+// Closes connection pool of queue
+func Close() error {
+	return pool.Close()
+}
@@ -0,0 +1,60 @@
+// Standard grpc health checking for microservices
+
+package micromiddleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// How often backing database is pinged
+const healthCheckInterval = 5 * time.Second
+
+// Timeout of one database ping
+const healthCheckTimeout = 2 * time.Second
+
+// Registers grpc.health.v1 service, reports NOT_SERVING while ping of the backing database fails
+// Pings stop when ctx is done
+func RegisterHealth(ctx context.Context, server *grpc.Server, ping func(ctx context.Context) error) {
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(server, hs)
+	go watchHealth(ctx, hs, ping)
+}
+
+// Pings database by timer and updates serving status until ctx is done
+func watchHealth(ctx context.Context, hs *health.Server, ping func(ctx context.Context) error) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := ping(pingCtx)
+		cancel()
+		if ctx.Err() != nil {
+			hs.Shutdown()
+			return
+		}
+		if err != nil {
+			logger.Warnf("Health check failed: %s", err)
+			hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		} else {
+			hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			hs.Shutdown()
+			return
+		}
+	}
+}
+
+// Health checks do not require token, so probes can reach them
+func isHealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/")
+}
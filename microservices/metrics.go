@@ -0,0 +1,70 @@
+// Prometheus metrics of microservices: grpc calls and database operations
+
+package micromiddleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chat_grpc_requests_total",
+		Help: "Number of handled grpc requests by method and status code",
+	}, []string{"method", "code"})
+
+	grpcLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chat_grpc_request_duration_seconds",
+		Help:    "Latency of grpc requests by method",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	dbLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chat_db_operation_duration_seconds",
+		Help:    "Latency of database operations by database and operation",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"db", "operation"})
+)
+
+// Counts requests and measures latency per grpc method
+func MetricsInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	reply, err := handler(ctx, req)
+
+	grpcLatency.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+	grpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+	return reply, err
+}
+
+// Records latency of database operation, use with defer: defer ObserveDB("redis", "hgetall", time.Now())
+func ObserveDB(db, operation string, start time.Time) {
+	dbLatency.WithLabelValues(db, operation).Observe(time.Since(start).Seconds())
+}
+
+// Serves /metrics on the address in background, returns server for shutdown
+func ServeMetrics(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("Metrics server stopped: %s", err)
+		}
+	}()
+
+	return server
+}
@@ -3,16 +3,18 @@ package micromiddleware
 
 import (
 	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
 	"chat_room_go/utils/logs"
+	"chat_room_go/utils/tracing"
 	"context"
 	"fmt"
 	"time"
 
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/tap"
 )
 
@@ -34,15 +36,16 @@ func LogInterceptor(
 	start := time.Now()
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		logger.Errorf("Retrieving metadata is failed")
+		logs.With(ctx, logger).Errorf("Retrieving metadata is failed")
 	}
 
+	log := logs.With(ctx, logger)
 	reply, err := handler(ctx, req)
 	if err != nil {
-		logger.Errorf("Error, during handling request. %s", err)
+		LogError(log, "Error, during handling request.", err)
 	}
 
-	logger.Infow("Recieved request",
+	log.Infow("Recieved request",
 		"method", info.FullMethod,
 		"request", fmt.Sprintf("%#v", req),
 		"reply", fmt.Sprintf("%#v", reply),
@@ -54,12 +57,45 @@ func LogInterceptor(
 	return reply, err
 }
 
+// Logs error of request handling, errors caused by the request (not found, invalid and others) are not errors of the service and go to Info
+func LogError(log *zap.SugaredLogger, message string, err error) {
+	if errs.ClientSide(err) {
+		log.Infof("%s \"%s\"", message, err)
+		return
+	}
+	log.Errorf("%s \"%s\"", message, err)
+}
+
+// Takes request id and trace context from metadata, starts server span, must be the first in chain
+func TracingInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if requestIDs := ExtractIncoming(ctx).Get(logs.RequestIDKey); requestIDs != "" {
+		ctx = logs.WithRequestID(ctx, requestIDs)
+	}
+	ctx, span := tracing.Tracer().Start(tracing.Extract(ctx), info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	reply, err := handler(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	return reply, err
+}
+
 // Checks authorization header and token
 func AuthInterceptor(ctx context.Context,
 	req interface{},
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler) (interface{}, error) {
 
+	if isHealthCheck(info.FullMethod) {
+		return handler(ctx, req)
+	}
 	if err := authorize(ctx); err != nil {
 		return nil, err
 	}
@@ -74,33 +110,62 @@ func AuthInterceptor(ctx context.Context,
 func authorize(ctx context.Context) error {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Errorf(codes.InvalidArgument, "Retrieving metadata is failed")
+		return errs.New(errs.InvalidArgument, errs.ReasonMetadataMissing, "Retrieving metadata is failed")
 	}
 
 	authHeader, ok := md["authorization"]
 	if !ok || len(authHeader) != 1 {
-		return status.Errorf(codes.Unauthenticated, "Authorization token is not supplied")
+		return errs.New(errs.Unauthenticated, errs.ReasonUnauthenticated, "Authorization token is not supplied")
 	}
 
 	token := authHeader[0]
-	err := validateToken(token)
-	if err != nil {
-		return status.Errorf(codes.Unauthenticated, err.Error())
-	}
-	return nil
+	return validateToken(token)
 }
 
 // Validates the token
 func validateToken(token string) error {
 	if token != TokenAuth {
-		return status.Errorf(codes.Unauthenticated, "Wrong token")
+		return errs.New(errs.Unauthenticated, errs.ReasonUnauthenticated, "Wrong token")
 	}
 	return nil
 }
 
+// Converts errors of handlers to grpc statuses with details, must be the last in chain
+func ErrorInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	reply, err := handler(ctx, req)
+	return reply, errs.ToStatus(err)
+}
+
+// Returns single value of incoming metadata key, InvalidArgument error if it is not supplied
+func RequiredMetadata(ctx context.Context, key string) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errs.New(errs.InvalidArgument, errs.ReasonMetadataMissing, "Metadata was not found")
+	}
+	values := md.Get(key)
+	if len(values) != 1 {
+		return "", &errs.Error{Kind: errs.InvalidArgument, Reason: errs.ReasonMetadataMissing, Message: key + " is not supplied", Field: key}
+	}
+	return values[0], nil
+}
+
 // NiceMD is a convenience wrapper definiting extra functions on the metadata.
 type NiceMD metadata.MD
 
+// Returns first value of the key, empty if there is none
+func (m NiceMD) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
 // ExtractIncoming extracts an inbound metadata from the server-side context.
 //
 // This function always returns a NiceMD wrapper of the metadata.MD, in case the context doesn't have metadata it returns
@@ -5,57 +5,76 @@ package main
 import (
 	config "chat_room_go/utils/conf"
 	"chat_room_go/utils/logs"
+	"chat_room_go/utils/tracing"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net"
+	"time"
 
 	mmw "chat_room_go/microservices"
 
-	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	mongoservice "chat_room_go/microservices/mongodb"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
-var logger *zap.SugaredLogger
-var wl logs.WriterToClickHouse
-
-func init() {
-	logger = logs.InitDirLogger(config.Config.MongoAdapter.PathToLogs)
-}
-
 // Listen and serve grpc
 func main() {
-	defer logger.Sync()
-	defer wl.GrpcConn.Close()
-
 	creds, err := loadTLSCredentials()
 	if err != nil {
-		logger.Fatal("cannot load TLS credentials: ", err)
+		log.Fatal("cannot load TLS credentials: ", err)
+	}
+	shutdownTracing, err := tracing.Init("chat-mongodb")
+	if err != nil {
+		log.Fatal("cannot init tracing: ", err)
 	}
 	mmw.TokenAuth = config.Config.MongoAdapter.TokenAuth
 	server := grpc.NewServer(
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(mmw.LogInterceptor, mmw.AuthInterceptor)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(mmw.TracingInterceptor, mmw.LogInterceptor, mmw.MetricsInterceptor, mmw.AuthInterceptor, mmw.ErrorInterceptor)),
 		grpc.InTapHandle(mmw.RateLimiter),
 	)
-	grpcconnector.RegisterWriterServer(server, RPCWriter{})
-	grpcconnector.RegisterReaderServer(server, RPCReader{})
+	// Background work of the service: health checks
+	background, stopBackground := context.WithCancel(context.Background())
+	mongoservice.Register(background, server)
+	indexCtx, cancelIndex := context.WithTimeout(context.Background(), time.Minute)
+	if err := mongoservice.CreateIndexes(indexCtx); err != nil {
+		log.Println("indexes are not created: ", err)
+	}
+	cancelIndex()
 
 	lis, err := net.Listen("tcp", config.Config.MongoAdapter.IntURL)
 	if err != nil {
 		log.Fatalln("cant listen port", err)
 	}
-	defer lis.Close()
 
 	fmt.Println("starting server at ", config.Config.MongoAdapter.IntURL)
-	logger.Debugf("Recieved request")
-	server.Serve(lis)
+	metricsServer := mmw.ServeMetrics(config.Config.MongoAdapter.MetricsURL)
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			log.Println("server stopped: ", err)
+		}
+	}()
+
+	sig := mmw.WaitForSignal()
+	fmt.Println("received ", sig, ", shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), config.Config.ShutdownDuration())
+	defer cancel()
+	metricsServer.Shutdown(ctx)
+	defer shutdownTracing(ctx)
+	// Health turns NOT_SERVING, so probes stop sending requests
+	stopBackground()
+	mmw.GracefulStop(ctx, server)
+	if err := mongoservice.Close(); err != nil {
+		log.Println("error during cleanup: ", err)
+	}
+	logs.Logger.Sync()
 }
 
 // Establich TLS
@@ -0,0 +1,207 @@
+// Implements Edit, Delete and Purge of Writer service, Edit and Delete publish events for outgoing webhooks
+
+package mongoservice
+
+import (
+	mmw "chat_room_go/microservices"
+	"chat_room_go/microservices/events"
+	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// grpc Edit implementation
+func (w RPCWriter) Edit(ctx context.Context, i *grpcconnector.EditRequest) (*grpcconnector.MessageInfo, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
+	}
+	if i.Message == "" {
+		return nil, errs.Invalid("message", "message is not supplied")
+	}
+
+	defer mmw.ObserveDB("mongodb", "update", time.Now())
+	var doc messageDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		filter, err := authorFilter(ctx, collection, i.Id, i.Room, i.Name, &doc)
+		if err != nil {
+			return err
+		}
+		doc.Message, doc.Edited = i.Message, i.Time
+		_, err = collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"message": doc.Message, "edited": doc.Edited}})
+		return err
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during message editing", err)
+		return nil, errs.Database(err)
+	}
+
+	toReturn := doc.infoFor(i.Name)
+	events.Publish(ctx, events.MessageEdited, messageEvent(toReturn))
+	return toReturn, nil
+}
+
+// grpc Delete implementation
+func (w RPCWriter) Delete(ctx context.Context, i *grpcconnector.DeleteRequest) (*grpcconnector.DeleteResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
+	}
+
+	defer mmw.ObserveDB("mongodb", "delete", time.Now())
+	var doc messageDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		filter, err := authorFilter(ctx, collection, i.Id, i.Room, i.Name, &doc)
+		if err != nil {
+			return err
+		}
+		if _, err = collection.DeleteOne(ctx, filter); err != nil {
+			return err
+		}
+		// Replies go with the first message of the thread, without events of their own
+		if doc.Parent == "" {
+			_, err = collection.DeleteMany(ctx, bson.M{"parent": doc.ID.Hex()})
+		}
+		return err
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during message deletion", err)
+		return nil, errs.Database(err)
+	}
+
+	toReturn := doc.info()
+	event := messageEvent(toReturn)
+	event.Text = ""
+	events.Publish(ctx, events.MessageDeleted, event)
+	return &grpcconnector.DeleteResponse{Result: toReturn}, nil
+}
+
+// Finds message of the room into doc, checks that name is its author (when name is set), returns filter of the message
+func authorFilter(ctx context.Context, collection *mongo.Collection, id, room, name string, doc *messageDoc) (bson.M, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errs.Invalid("id", "id is not a message id")
+	}
+	filter := bson.M{"$and": bson.A{roomFilter(room), bson.M{"_id": oid}}}
+	err = collection.FindOne(ctx, filter).Decode(doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, errs.New(errs.NotFound, errs.ReasonMessageNotFound, "Message not found")
+	} else if err != nil {
+		return nil, err
+	}
+	// Bots have no accounts, their messages are not owned by users with the same name
+	if name != "" && (doc.Name != name || doc.Bot) {
+		return nil, errs.New(errs.PermissionDenied, errs.ReasonNotAuthor, "Only author can change the message")
+	}
+	return bson.M{"_id": oid}, nil
+}
+
+// Data of message event
+func messageEvent(m *grpcconnector.MessageInfo) events.Message {
+	return events.Message{ID: m.Id, Room: m.Room, Author: m.Name, Text: m.Message, Time: m.Time, Bot: m.Bot, Edited: m.Edited, Parent: m.Parent}
+}
+
+// grpc Purge implementation, bulk deletion does not publish events
+func (w RPCWriter) Purge(ctx context.Context, i *grpcconnector.PurgeRequest) (*grpcconnector.PurgeResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
+	}
+	// Empty request would delete everything
+	if i.Room == "" && i.Name == "" && i.Before == 0 {
+		return nil, errs.Invalid("room", "room, name or before must be supplied")
+	}
+
+	filter := bson.A{}
+	if i.Room != "" {
+		filter = append(filter, roomFilter(i.Room))
+	}
+	if i.Name != "" {
+		filter = append(filter, bson.M{"name": i.Name})
+	}
+	if i.Before != 0 {
+		// Id of message keeps time of its creation
+		before := primitive.NewObjectIDFromTimestamp(time.Unix(i.Before, 0))
+		filter = append(filter, bson.M{"_id": bson.M{"$lt": before}})
+	}
+
+	defer mmw.ObserveDB("mongodb", "delete_many", time.Now())
+	var deleted int64
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		// Replies of purged threads go with them, whatever their author and time
+		roots, err := purgedRoots(ctx, collection, filter)
+		if err != nil {
+			return err
+		}
+		res, err := collection.DeleteMany(ctx, bson.M{"$and": filter})
+		if err != nil {
+			return err
+		}
+		deleted = res.DeletedCount
+		for start := 0; start < len(roots); start += purgeBatch {
+			end := start + purgeBatch
+			if end > len(roots) {
+				end = len(roots)
+			}
+			res, err := collection.DeleteMany(ctx, bson.M{"parent": bson.M{"$in": roots[start:end]}})
+			if err != nil {
+				return err
+			}
+			deleted += res.DeletedCount
+		}
+		return nil
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during messages purging", err)
+		return nil, errs.Database(err)
+	}
+
+	log.Infof("Purged %d messages", deleted)
+	return &grpcconnector.PurgeResponse{Deleted: deleted}, nil
+}
+
+// Replies are purged by this number of threads at once
+const purgeBatch = 1000
+
+// Returns ids of messages of room streams matching filter, as replies refer to them
+func purgedRoots(ctx context.Context, collection *mongo.Collection, filter bson.A) (bson.A, error) {
+	rootFilter := append(bson.A{bson.M{"parent": bson.M{"$exists": false}}}, filter...)
+	cur, err := collection.Find(ctx, bson.M{"$and": rootFilter}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []messageDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	roots := make(bson.A, len(docs))
+	for n := range docs {
+		roots[n] = docs[n].ID.Hex()
+	}
+	return roots, nil
+}
@@ -0,0 +1,46 @@
+// Indexes of collections from config, created once when the microservice starts
+// Creation of an existing index is a no-op in mongodb, so every start runs it again
+
+package mongoservice
+
+import (
+	config "chat_room_go/utils/conf"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Indexes by name of collection in config
+func collectionIndexes() map[string][]mongo.IndexModel {
+	return map[string][]mongo.IndexModel{
+		// Messages of a room after a message: history, unread counts; replies of threads
+		config.Config.MongoAdapter.CollectionName: {
+			{Keys: bson.D{{Key: "room", Value: 1}, {Key: "_id", Value: 1}}},
+			{Keys: bson.D{{Key: "parent", Value: 1}, {Key: "_id", Value: 1}}},
+		},
+		// User reports message once, even if two requests race
+		config.Config.MongoAdapter.ReportsCollectionName: {
+			{Keys: bson.D{{Key: "message_id", Value: 1}, {Key: "reporter", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+	}
+}
+
+// Creates indexes of all collections, error of one collection does not stop the others
+func CreateIndexes(ctx context.Context) error {
+	var firstErr error
+	for collectionName, models := range collectionIndexes() {
+		err := withCollection(ctx, config.Config.MongoAdapter.DbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+			_, err := collection.Indexes().CreateMany(ctx, models)
+			return err
+		})
+		if err != nil {
+			logger.Errorf("Error during index creation of %s \"%s\"", collectionName, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
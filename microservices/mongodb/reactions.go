@@ -0,0 +1,120 @@
+// Implements Reactions service: reactions are kept on the message as map of emoji to logins of users
+
+package mongoservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Max number of different emoji on one message
+const maxMessageReactions = 20
+
+type RPCReactions struct{}
+
+// grpc ToggleReaction implementation
+func (w RPCReactions) ToggleReaction(ctx context.Context, i *grpcconnector.ToggleReactionRequest) (*grpcconnector.MessageInfo, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case i.Login == "":
+		return nil, errs.Invalid("login", "login is not supplied")
+	// Emoji is a key of the document
+	case i.Emoji == "" || strings.ContainsAny(i.Emoji, ".$"):
+		return nil, errs.Invalid("emoji", "emoji is not valid")
+	}
+
+	defer mmw.ObserveDB("mongodb", "toggle_reaction", time.Now())
+	var doc messageDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		filter, err := authorFilter(ctx, collection, i.Id, i.Room, "", &doc)
+		if err != nil {
+			return err
+		}
+		// Toggle and cap are checked by mongodb in one update, so concurrent toggles do not race
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = collection.FindOneAndUpdate(ctx, reactionFilter(filter, i.Emoji), toggleReaction(i.Emoji, i.Login), opts).Decode(&doc)
+		if err != mongo.ErrNoDocuments {
+			return err
+		}
+		// Message is deleted meanwhile or has no room for a new emoji
+		if n, err := collection.CountDocuments(ctx, filter); err != nil || n == 0 {
+			if err == nil {
+				err = errs.New(errs.NotFound, errs.ReasonMessageNotFound, "Message not found")
+			}
+			return err
+		}
+		return errs.Invalid("emoji", "Message has too many different reactions")
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during reaction toggling", err)
+		return nil, errs.Database(err)
+	}
+
+	return doc.infoFor(i.Login), nil
+}
+
+// Matches the message, if it has the emoji already or has less than maxMessageReactions different ones
+func reactionFilter(filter bson.M, emoji string) bson.M {
+	return bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+		bson.M{"reactions." + emoji: bson.M{"$exists": true}},
+		bson.M{"$expr": bson.M{"$lt": bson.A{bson.M{"$size": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$reactions", bson.M{}}}}}, maxMessageReactions}}},
+	}}}}
+}
+
+// Update pipeline, that takes reaction of the login back if it is there or adds it, emoji without users goes away
+// Login is $literal, values starting with $ would be field paths otherwise
+func toggleReaction(emoji, login string) mongo.Pipeline {
+	logins := bson.M{"$ifNull": bson.A{"$reactions." + emoji, bson.A{}}}
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"reactions." + emoji: bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{bson.M{"$literal": login}, logins}},
+			bson.M{"$filter": bson.M{"input": logins, "cond": bson.M{"$ne": bson.A{"$$this", bson.M{"$literal": login}}}}},
+			bson.M{"$concatArrays": bson.A{logins, bson.A{bson.M{"$literal": login}}}},
+		}}}}},
+		{{Key: "$set", Value: bson.M{"reactions": bson.M{"$arrayToObject": bson.M{"$filter": bson.M{
+			"input": bson.M{"$objectToArray": "$reactions"},
+			"cond":  bson.M{"$gt": bson.A{bson.M{"$size": "$$this.v"}, 0}},
+		}}}}}},
+	}
+}
+
+// Converts stored reactions to grpc ones, sorted by emoji, reactions of viewer are marked
+func reactionsInfo(reactions map[string][]string, viewer string) []*grpcconnector.Reaction {
+	toReturn := make([]*grpcconnector.Reaction, 0, len(reactions))
+	for emoji, logins := range reactions {
+		if len(logins) == 0 {
+			continue
+		}
+		toReturn = append(toReturn, &grpcconnector.Reaction{Emoji: emoji, Count: int32(len(logins)), Logins: logins, Me: viewer != "" && hasLogin(logins, viewer)})
+	}
+	sort.Slice(toReturn, func(a, b int) bool { return toReturn[a].Emoji < toReturn[b].Emoji })
+	return toReturn
+}
+
+func hasLogin(logins []string, login string) bool {
+	for _, l := range logins {
+		if l == login {
+			return true
+		}
+	}
+	return false
+}
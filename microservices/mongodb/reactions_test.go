@@ -0,0 +1,49 @@
+package mongoservice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReactionsInfo(t *testing.T) {
+	reactions := map[string][]string{
+		"👍": {"alice", "bob"},
+		"🎉": {"carol"},
+		// Left by the last user, not shown
+		"❤️": {},
+	}
+	type reaction struct {
+		emoji  string
+		count  int32
+		logins []string
+		me     bool
+	}
+	tests := []struct {
+		reactions map[string][]string
+		viewer    string
+		want      []reaction
+	}{
+		{nil, "bob", []reaction{}},
+		{reactions, "", []reaction{
+			{"🎉", 1, []string{"carol"}, false},
+			{"👍", 2, []string{"alice", "bob"}, false},
+		}},
+		{reactions, "bob", []reaction{
+			{"🎉", 1, []string{"carol"}, false},
+			{"👍", 2, []string{"alice", "bob"}, true},
+		}},
+		{reactions, "carol", []reaction{
+			{"🎉", 1, []string{"carol"}, true},
+			{"👍", 2, []string{"alice", "bob"}, false},
+		}},
+	}
+	for _, tt := range tests {
+		got := []reaction{}
+		for _, r := range reactionsInfo(tt.reactions, tt.viewer) {
+			got = append(got, reaction{r.Emoji, r.Count, r.Logins, r.Me})
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("reactionsInfo(%v, %q) = %v, want %v", tt.reactions, tt.viewer, got, tt.want)
+		}
+	}
+}
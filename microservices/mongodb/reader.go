@@ -1,94 +1,158 @@
 // implementation of grpc read, reads data from mongodb
 // Everytime we establish new connection to database. TODO: remove this feature
 
-package main
+package mongoservice
 
 import (
-	"context"
-	"log"
+	mmw "chat_room_go/microservices"
 	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"go.mongodb.org/mongo-driver/mongo/readpref"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
+// Number of messages returned, when request does not limit it
+const defaultReadNumber = 100
+
 type RPCReader struct{}
 
+// Message as stored in mongodb, messages written before rooms have no room
+type messageDoc struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty"`
+	Time    string             `bson:"time"`
+	Name    string             `bson:"name"`
+	Message string             `bson:"message"`
+	Room    string             `bson:"room,omitempty"`
+	Bot     bool               `bson:"bot,omitempty"`
+	Edited  string             `bson:"edited,omitempty"`
+	Nick    string             `bson:"nick,omitempty"`
+	// Logins of users by emoji they reacted with
+	Reactions map[string][]string `bson:"reactions,omitempty"`
+	// Id of the thread, replies are not in the room stream
+	Parent string `bson:"parent,omitempty"`
+	// Replies of the thread are counted on reading, not stored
+	Replies   int32  `bson:"-"`
+	LastReply string `bson:"-"`
+}
+
+// Converts stored message to grpc one
+func (d *messageDoc) info() *grpcconnector.MessageInfo {
+	return d.infoFor("")
+}
+
+// Converts stored message to grpc one, as the viewer sees it: own reactions are marked
+func (d *messageDoc) infoFor(viewer string) *grpcconnector.MessageInfo {
+	room := d.Room
+	if room == "" {
+		room = defaultRoom
+	}
+	return &grpcconnector.MessageInfo{Id: d.ID.Hex(), Time: d.Time, Name: d.Name, Message: d.Message, Room: room, Bot: d.Bot, Edited: d.Edited, Nick: d.Nick,
+		Reactions: reactionsInfo(d.Reactions, viewer), Parent: d.Parent, Replies: d.Replies, LastReply: d.LastReply}
+}
+
+// Filter of messages of the room, default room also has messages without room
+func roomFilter(room string) bson.M {
+	if room == "" || room == defaultRoom {
+		return bson.M{"$or": bson.A{bson.M{"room": defaultRoom}, bson.M{"room": bson.M{"$exists": false}}}}
+	}
+	return bson.M{"room": room}
+}
+
 // grpc Read implementation
 func (w RPCReader) Read(ctx context.Context, i *grpcconnector.ReadRequest) (*grpcconnector.ReadResponse, error) {
-	logger.Info(ctx, i)
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return &grpcconnector.ReadResponse{Status: 404, Desription: "Metadata was not found"}, status.Errorf(codes.NotFound, "Metadata was not found")
-	}
-	dbNames, ok := md["dbname"]
-	if !ok || len(dbNames) != 1 {
-		return &grpcconnector.ReadResponse{Status: 404, Desription: "dbName is not supplied"}, status.Errorf(codes.NotFound, "dbName is not supplied")
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
 	}
-	dbName := dbNames[0]
-	collectionNames, ok := md["collectionname"]
-	if !ok || len(collectionNames) != 1 {
-		return &grpcconnector.ReadResponse{Status: 404, Desription: "collection name is not supplied"}, status.Errorf(codes.NotFound, "collection name is not supplied")
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
 	}
-	collectionName := collectionNames[0]
-	toReturn, err := readFromDB(dbName, collectionName, i.Number)
+	toReturn, hasMore, err := readFromDB(ctx, dbName, collectionName, i)
 	if err != nil {
-		logger.Errorf("Error during table reading \"%s\"", err)
-		return &grpcconnector.ReadResponse{Status: 500, Desription: "Error during table insertion"}, status.Errorf(codes.NotFound, "Error during table insertion: %s", err)
+		mmw.LogError(log, "Error during table reading", err)
+		return nil, errs.Database(err)
 	}
 
-	logger.Info(toReturn)
-	return &grpcconnector.ReadResponse{Results: toReturn, Status: 0, Desription: "Ok"}, nil
+	log.Info(toReturn)
+	return &grpcconnector.ReadResponse{Results: toReturn, HasMore: hasMore}, nil
 }
 
-// TODO: return only numberToRecieve values, also sorted
-func readFromDB(dbName, collectionName string, numberToRecieve int32) ([]*grpcconnector.MessageInfo, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dbURL))
-	defer func() {
-		if err = client.Disconnect(ctx); err != nil {
-			panic(err)
-		}
-	}()
-	defer func() {
-		if r := recover(); r != nil {
-			logger.Errorf("Recovered in writeToDB", r)
-		}
-	}()
-	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-	err = client.Ping(ctx, readpref.Primary())
-
-	collection := client.Database(dbName).Collection(collectionName)
-	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	cur, err := collection.Find(ctx, bson.D{})
+// grpc Get implementation
+func (w RPCReader) Get(ctx context.Context, i *grpcconnector.GetRequest) (*grpcconnector.MessageInfo, error) {
+	log := logs.With(ctx, logger)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
 	if err != nil {
 		return nil, err
 	}
-	defer cur.Close(ctx)
-	toReturn := make([]*grpcconnector.MessageInfo, 0, numberToRecieve)
-	for cur.Next(ctx) {
-		var result grpcconnector.MessageInfo
-		err := cur.Decode(&result)
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
+	}
+
+	defer mmw.ObserveDB("mongodb", "find_message", time.Now())
+	var message messageDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		_, err := authorFilter(ctx, collection, i.Id, i.Room, "", &message)
+		return err
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during message reading", err)
+		return nil, errs.Database(err)
+	}
+
+	return message.infoFor(i.Viewer), nil
+}
+
+// Returns last 'Number' messages of the room stream (older than 'Before' if set) in chronological order, with numbers of replies
+func readFromDB(ctx context.Context, dbName, collectionName string, i *grpcconnector.ReadRequest) ([]*grpcconnector.MessageInfo, bool, error) {
+	defer mmw.ObserveDB("mongodb", "find", time.Now())
+	number := int64(i.Number)
+	if number <= 0 {
+		number = defaultReadNumber
+	}
+	filter := bson.A{roomFilter(i.Room), bson.M{"parent": bson.M{"$exists": false}}}
+	if i.Before != "" {
+		before, err := primitive.ObjectIDFromHex(i.Before)
+		if err != nil {
+			return nil, false, errs.Invalid("before", "before is not a message id")
+		}
+		filter = append(filter, bson.M{"_id": bson.M{"$lt": before}})
+	}
+
+	var docs []messageDoc
+	err := withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		// One more, than asked, tells if there are older messages
+		opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(number + 1)
+		cur, err := collection.Find(ctx, bson.M{"$and": filter}, opts)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		toReturn = append(toReturn, &result)
+		if err := cur.All(ctx, &docs); err != nil {
+			return err
+		}
+		return addReplies(ctx, collection, docs)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := int64(len(docs)) > number
+	if hasMore {
+		docs = docs[:number]
 	}
-	if err := cur.Err(); err != nil {
-		log.Fatal(err)
+	toReturn := make([]*grpcconnector.MessageInfo, len(docs))
+	for n := range docs {
+		toReturn[len(docs)-1-n] = docs[n].infoFor(i.Viewer)
 	}
 
-	return toReturn, nil
+	return toReturn, hasMore, nil
 }
@@ -0,0 +1,226 @@
+// Implements Reports service: reports of messages are stored in their own collection with a copy of the message,
+// so moderators see what was reported even after the message is edited or deleted
+
+package mongoservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Statuses of reports
+const (
+	ReportOpen     = "open"
+	ReportResolved = "resolved"
+)
+
+// Reports returned, when request does not limit their number
+const defaultReportsNumber = 100
+
+type RPCReports struct{}
+
+// Report as stored in mongodb
+type reportDoc struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	MessageID  string             `bson:"message_id"`
+	Room       string             `bson:"room"`
+	Author     string             `bson:"author"`
+	Text       string             `bson:"text"`
+	Reporter   string             `bson:"reporter"`
+	Reason     string             `bson:"reason"`
+	Time       string             `bson:"time"`
+	Status     string             `bson:"status"`
+	Decision   string             `bson:"decision,omitempty"`
+	ResolvedBy string             `bson:"resolved_by,omitempty"`
+	ResolvedAt string             `bson:"resolved_at,omitempty"`
+	Bot        bool               `bson:"bot,omitempty"`
+}
+
+func (d *reportDoc) info() *grpcconnector.ReportInfo {
+	return &grpcconnector.ReportInfo{
+		Id:         d.ID.Hex(),
+		MessageId:  d.MessageID,
+		Room:       d.Room,
+		Author:     d.Author,
+		Text:       d.Text,
+		Reporter:   d.Reporter,
+		Reason:     d.Reason,
+		Time:       d.Time,
+		Status:     d.Status,
+		Decision:   d.Decision,
+		ResolvedBy: d.ResolvedBy,
+		ResolvedAt: d.ResolvedAt,
+		Bot:        d.Bot,
+	}
+}
+
+// grpc CreateReport implementation, user reports message once
+func (w RPCReports) CreateReport(ctx context.Context, i *grpcconnector.CreateReportRequest) (*grpcconnector.ReportInfo, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	messagesName, err := mmw.RequiredMetadata(ctx, "messagescollectionname")
+	if err != nil {
+		return nil, err
+	}
+	r := i.GetReport()
+	switch {
+	case r.GetReporter() == "":
+		return nil, errs.Invalid("report.reporter", "reporter is not supplied")
+	case r.GetReason() == "":
+		return nil, errs.Invalid("report.reason", "reason is not supplied")
+	}
+
+	defer mmw.ObserveDB("mongodb", "insert_report", time.Now())
+	doc := reportDoc{MessageID: r.MessageId, Room: r.Room, Reporter: r.Reporter, Reason: r.Reason, Time: r.Time, Status: ReportOpen}
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		var message messageDoc
+		if _, err := authorFilter(ctx, collection.Database().Collection(messagesName), r.MessageId, r.Room, "", &message); err != nil {
+			return err
+		}
+		if message.Name == r.Reporter && !message.Bot {
+			return errs.Invalid("report.message_id", "You can not report your own message")
+		}
+		doc.Author, doc.Text, doc.Room, doc.Bot = message.Name, message.Message, message.info().Room, message.Bot
+
+		// Unique index on message and reporter keeps one report, when requests race
+		res, err := collection.InsertOne(ctx, doc)
+		if mongo.IsDuplicateKeyError(err) {
+			return errs.New(errs.AlreadyExists, errs.ReasonReportExists, "You have already reported this message")
+		} else if err != nil {
+			return err
+		}
+		doc.ID = res.InsertedID.(primitive.ObjectID)
+		return nil
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during report insertion", err)
+		return nil, errs.Database(err)
+	}
+
+	return doc.info(), nil
+}
+
+// grpc ListReports implementation
+func (w RPCReports) ListReports(ctx context.Context, i *grpcconnector.ListReportsRequest) (*grpcconnector.ListReportsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	number := int64(i.Number)
+	if number <= 0 {
+		number = defaultReportsNumber
+	}
+	filter := bson.M{}
+	if i.Status != "" {
+		filter["status"] = i.Status
+	}
+
+	defer mmw.ObserveDB("mongodb", "find_reports", time.Now())
+	var docs []reportDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		opts := options.Find().SetSort(bson.M{"_id": -1}).SetLimit(number)
+		cur, err := collection.Find(ctx, filter, opts)
+		if err != nil {
+			return err
+		}
+		return cur.All(ctx, &docs)
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during reports reading", err)
+		return nil, errs.Database(err)
+	}
+
+	results := make([]*grpcconnector.ReportInfo, 0, len(docs))
+	for n := range docs {
+		results = append(results, docs[n].info())
+	}
+	return &grpcconnector.ListReportsResponse{Results: results}, nil
+}
+
+// grpc GetReport implementation
+func (w RPCReports) GetReport(ctx context.Context, i *grpcconnector.GetReportRequest) (*grpcconnector.ReportInfo, error) {
+	log := logs.With(ctx, logger)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	oid, err := primitive.ObjectIDFromHex(i.Id)
+	if err != nil {
+		return nil, errs.New(errs.NotFound, errs.ReasonReportNotFound, "Report not found")
+	}
+
+	defer mmw.ObserveDB("mongodb", "find_report", time.Now())
+	var doc reportDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		err := collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc)
+		if err == mongo.ErrNoDocuments {
+			return errs.New(errs.NotFound, errs.ReasonReportNotFound, "Report not found")
+		}
+		return err
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during report reading", err)
+		return nil, errs.Database(err)
+	}
+
+	return doc.info(), nil
+}
+
+// grpc ResolveReport implementation, returns the report with decision
+// Report goes from open to resolved in one update, so only one moderator resolves it
+func (w RPCReports) ResolveReport(ctx context.Context, i *grpcconnector.ResolveReportRequest) (*grpcconnector.ReportInfo, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	oid, err := primitive.ObjectIDFromHex(i.Id)
+	if err != nil {
+		return nil, errs.Invalid("id", "id is not a report id")
+	}
+	if i.Decision == "" {
+		return nil, errs.Invalid("decision", "decision is not supplied")
+	}
+
+	defer mmw.ObserveDB("mongodb", "update_reports", time.Now())
+	var doc reportDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		update := bson.M{"$set": bson.M{"status": ReportResolved, "decision": i.Decision, "resolved_by": i.ResolvedBy, "resolved_at": i.ResolvedAt}}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err := collection.FindOneAndUpdate(ctx, bson.M{"_id": oid, "status": ReportOpen}, update, opts).Decode(&doc)
+		if err == mongo.ErrNoDocuments {
+			if err := collection.FindOne(ctx, bson.M{"_id": oid}).Err(); err == mongo.ErrNoDocuments {
+				return errs.New(errs.NotFound, errs.ReasonReportNotFound, "Report not found")
+			} else if err != nil {
+				return err
+			}
+			return errs.New(errs.AlreadyExists, errs.ReasonReportResolved, "Report is already resolved")
+		} else if err != nil {
+			return err
+		}
+		_, err = collection.UpdateMany(ctx, bson.M{"message_id": doc.MessageID, "status": ReportOpen}, update)
+		return err
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during report update", err)
+		return nil, errs.Database(err)
+	}
+
+	return doc.info(), nil
+}
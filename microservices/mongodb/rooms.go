@@ -0,0 +1,166 @@
+// Implements Rooms service: rooms of the chat are stored in their own collection
+// Default room always exists, even if it is not stored
+
+package mongoservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RPCRooms struct{}
+
+// Room as stored in mongodb, name is the id
+type roomDoc struct {
+	Name      string `bson:"_id"`
+	Topic     string `bson:"topic"`
+	CreatedBy string `bson:"created_by"`
+	CreatedAt string `bson:"created_at"`
+}
+
+func (d *roomDoc) info() *grpcconnector.RoomInfo {
+	return &grpcconnector.RoomInfo{Name: d.Name, Topic: d.Topic, CreatedBy: d.CreatedBy, CreatedAt: d.CreatedAt}
+}
+
+// Returns db and collection from metadata
+func metadataCollection(ctx context.Context) (string, string, error) {
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return "", "", err
+	}
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return "", "", err
+	}
+	return dbName, collectionName, nil
+}
+
+// grpc CreateRoom implementation
+func (w RPCRooms) CreateRoom(ctx context.Context, i *grpcconnector.CreateRoomRequest) (*grpcconnector.RoomInfo, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if i.Room == nil || i.Room.Name == "" {
+		return nil, errs.Invalid("room.name", "room name is not supplied")
+	}
+	if i.Room.Name == defaultRoom {
+		return nil, errs.New(errs.AlreadyExists, errs.ReasonRoomExists, "Room already exists")
+	}
+
+	defer mmw.ObserveDB("mongodb", "insert_room", time.Now())
+	doc := roomDoc{Name: i.Room.Name, Topic: i.Room.Topic, CreatedBy: i.Room.CreatedBy, CreatedAt: i.Room.CreatedAt}
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		_, err := collection.InsertOne(ctx, doc)
+		return err
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, errs.New(errs.AlreadyExists, errs.ReasonRoomExists, "Room already exists")
+	}
+	if err != nil {
+		mmw.LogError(log, "Error during room insertion", err)
+		return nil, errs.Database(err)
+	}
+
+	return doc.info(), nil
+}
+
+// grpc GetRoom implementation
+func (w RPCRooms) GetRoom(ctx context.Context, i *grpcconnector.GetRoomRequest) (*grpcconnector.RoomInfo, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer mmw.ObserveDB("mongodb", "find_room", time.Now())
+	var doc roomDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		return collection.FindOne(ctx, bson.M{"_id": i.Name}).Decode(&doc)
+	})
+	if err == mongo.ErrNoDocuments {
+		if i.Name == defaultRoom {
+			return &grpcconnector.RoomInfo{Name: defaultRoom}, nil
+		}
+		return nil, errs.New(errs.NotFound, errs.ReasonRoomNotFound, "Room not found")
+	}
+	if err != nil {
+		mmw.LogError(log, "Error during room reading", err)
+		return nil, errs.Database(err)
+	}
+
+	return doc.info(), nil
+}
+
+// grpc SetTopic implementation, default room is stored on its first topic
+func (w RPCRooms) SetTopic(ctx context.Context, i *grpcconnector.SetTopicRequest) (*grpcconnector.RoomInfo, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer mmw.ObserveDB("mongodb", "update_room", time.Now())
+	var doc roomDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetUpsert(i.Name == defaultRoom)
+		return collection.FindOneAndUpdate(ctx, bson.M{"_id": i.Name}, bson.M{"$set": bson.M{"topic": i.Topic}}, opts).Decode(&doc)
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil, errs.New(errs.NotFound, errs.ReasonRoomNotFound, "Room not found")
+	}
+	if err != nil {
+		mmw.LogError(log, "Error during room update", err)
+		return nil, errs.Database(err)
+	}
+
+	return doc.info(), nil
+}
+
+// grpc ListRooms implementation, rooms are sorted by name
+func (w RPCRooms) ListRooms(ctx context.Context, i *grpcconnector.ListRoomsRequest) (*grpcconnector.ListRoomsResponse, error) {
+	log := logs.With(ctx, logger)
+	dbName, collectionName, err := metadataCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer mmw.ObserveDB("mongodb", "find_rooms", time.Now())
+	var docs []roomDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		cur, err := collection.Find(ctx, bson.M{})
+		if err != nil {
+			return err
+		}
+		return cur.All(ctx, &docs)
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during rooms reading", err)
+		return nil, errs.Database(err)
+	}
+
+	toReturn := []*grpcconnector.RoomInfo{{Name: defaultRoom}}
+	for n := range docs {
+		if docs[n].Name == defaultRoom {
+			toReturn[0] = docs[n].info()
+			continue
+		}
+		toReturn = append(toReturn, docs[n].info())
+	}
+	sort.Slice(toReturn, func(a, b int) bool { return toReturn[a].Name < toReturn[b].Name })
+
+	return &grpcconnector.ListRoomsResponse{Results: toReturn}, nil
+}
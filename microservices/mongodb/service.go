@@ -0,0 +1,75 @@
+// Mongodb microservice as a library, grpc services are registered by the caller
+// Used by the standalone server (cmd) and by all-in-one mode of main
+
+package mongoservice
+
+import (
+	mmw "chat_room_go/microservices"
+	"chat_room_go/microservices/events"
+	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+var logger *zap.SugaredLogger
+
+func init() {
+	logger = logs.InitDirLogger(config.Config.MongoAdapter.PathToLogs)
+}
+
+// Registers all grpc services of the microservice, including health service, that pings database until ctx is done
+func Register(ctx context.Context, server *grpc.Server) {
+	mmw.RegisterHealth(ctx, server, Ping)
+	grpcconnector.RegisterWriterServer(server, RPCWriter{})
+	grpcconnector.RegisterReaderServer(server, RPCReader{})
+	grpcconnector.RegisterRoomsServer(server, RPCRooms{})
+	grpcconnector.RegisterReportsServer(server, RPCReports{})
+	grpcconnector.RegisterReactionsServer(server, RPCReactions{})
+}
+
+// Checks that mongodb is reachable
+func Ping(ctx context.Context) error {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dbURL))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	return client.Ping(ctx, readpref.Primary())
+}
+
+// Connects to mongodb and runs fn with the collection, connection is closed afterwards
+// Everytime we establish new connection to database. TODO: remove this feature
+func withCollection(parent context.Context, dbName, collectionName string, fn func(ctx context.Context, collection *mongo.Collection) error) error {
+	ctx, cancel := context.WithTimeout(parent, 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dbURL))
+	if err != nil {
+		return errs.Wrap(errs.Unavailable, errs.ReasonDatabaseUnavailable, "Can not connect to mongodb", err)
+	}
+	// Request context might be cancelled already, disconnect anyway
+	defer client.Disconnect(context.Background())
+
+	err = client.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return errs.Wrap(errs.Unavailable, errs.ReasonDatabaseUnavailable, "Mongodb is unavailable", err)
+	}
+
+	return fn(ctx, client.Database(dbName).Collection(collectionName))
+}
+
+// Releases resources of the microservice, flushes its logger
+func Close() error {
+	events.Close()
+	return logger.Sync()
+}
@@ -0,0 +1,148 @@
+// Implements Thread of Reader service: replies keep id of the first message of the thread in parent
+// Threads are one level deep, reply to a reply goes to the same thread, room stream has no replies
+
+package mongoservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Replies of a thread, as counted by aggregation
+type threadStats struct {
+	ID        string `bson:"_id"`
+	Replies   int32  `bson:"replies"`
+	LastReply string `bson:"last_reply"`
+}
+
+// grpc Thread implementation
+func (w RPCReader) Thread(ctx context.Context, i *grpcconnector.ThreadRequest) (*grpcconnector.ThreadResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
+	}
+	number := int64(i.Number)
+	if number <= 0 {
+		number = defaultReadNumber
+	}
+	var before primitive.ObjectID
+	if i.Before != "" {
+		if before, err = primitive.ObjectIDFromHex(i.Before); err != nil {
+			return nil, errs.Invalid("before", "before is not a message id")
+		}
+	}
+
+	defer mmw.ObserveDB("mongodb", "find_thread", time.Now())
+	var root messageDoc
+	var docs []messageDoc
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		var message messageDoc
+		if _, err := authorFilter(ctx, collection, i.Id, i.Room, "", &message); err != nil {
+			return err
+		}
+		// Reply stands for its thread
+		root = message
+		if message.Parent != "" {
+			if _, err := authorFilter(ctx, collection, message.Parent, i.Room, "", &root); err != nil {
+				return err
+			}
+		}
+
+		filter := bson.M{"parent": root.ID.Hex()}
+		if i.Before != "" {
+			filter["_id"] = bson.M{"$lt": before}
+		}
+		// One more, than asked, tells if there are older replies
+		opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(number + 1)
+		cur, err := collection.Find(ctx, filter, opts)
+		if err != nil {
+			return err
+		}
+		if err := cur.All(ctx, &docs); err != nil {
+			return err
+		}
+		roots := []messageDoc{root}
+		if err := addReplies(ctx, collection, roots); err != nil {
+			return err
+		}
+		root = roots[0]
+		return nil
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during thread reading", err)
+		return nil, errs.Database(err)
+	}
+
+	hasMore := int64(len(docs)) > number
+	if hasMore {
+		docs = docs[:number]
+	}
+	replies := make([]*grpcconnector.MessageInfo, len(docs))
+	for n := range docs {
+		replies[len(docs)-1-n] = docs[n].infoFor(i.Viewer)
+	}
+	return &grpcconnector.ThreadResponse{Parent: root.infoFor(i.Viewer), Replies: replies, HasMore: hasMore}, nil
+}
+
+// Sets number of replies and time of the last one on messages of the room stream
+func addReplies(ctx context.Context, collection *mongo.Collection, docs []messageDoc) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	ids := make(bson.A, 0, len(docs))
+	for n := range docs {
+		ids = append(ids, docs[n].ID.Hex())
+	}
+	cur, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"parent": bson.M{"$in": ids}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$parent", "replies": bson.M{"$sum": 1}, "last_reply": bson.M{"$max": "$time"}}}},
+	})
+	if err != nil {
+		return err
+	}
+	var stats []threadStats
+	if err := cur.All(ctx, &stats); err != nil {
+		return err
+	}
+
+	byID := make(map[string]threadStats, len(stats))
+	for _, s := range stats {
+		byID[s.ID] = s
+	}
+	for n := range docs {
+		if s, ok := byID[docs[n].ID.Hex()]; ok {
+			docs[n].Replies, docs[n].LastReply = s.Replies, s.LastReply
+		}
+	}
+	return nil
+}
+
+// Returns id of the thread for reply to message 'parent' of the room: id of the first message of the thread
+func threadOf(ctx context.Context, collection *mongo.Collection, parent, room string) (string, error) {
+	if _, err := primitive.ObjectIDFromHex(parent); err != nil {
+		return "", errs.Invalid("parent", "parent is not a message id")
+	}
+	var doc messageDoc
+	if _, err := authorFilter(ctx, collection, parent, room, "", &doc); err != nil {
+		return "", err
+	}
+	if doc.Parent != "" {
+		return doc.Parent, nil
+	}
+	return doc.ID.Hex(), nil
+}
@@ -0,0 +1,110 @@
+// Implements CountUnread of Reader service: read markers are kept by redis microservice, here messages after them are counted
+// Only messages of the room stream are counted, replies and messages of the viewer are not
+// Rooms are counted by one aggregation per chunk of rooms, every room by its own $unionWith branch, that uses index on room and _id
+
+package mongoservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/mongodb/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Unread messages are counted up to this number, clients show it as "99+"
+const maxUnreadCount = 100
+
+// Max number of rooms counted by one aggregation
+const maxUnreadRooms = 100
+
+// grpc CountUnread implementation
+func (w RPCReader) CountUnread(ctx context.Context, i *grpcconnector.CountUnreadRequest) (*grpcconnector.CountUnreadResponse, error) {
+	log := logs.With(ctx, logger)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
+	}
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
+	}
+	filters := make([]bson.A, len(i.Markers))
+	for n, marker := range i.Markers {
+		filters[n] = bson.A{roomFilter(marker.Room), bson.M{"parent": bson.M{"$exists": false}}}
+		if i.Viewer != "" {
+			filters[n] = append(filters[n], bson.M{"name": bson.M{"$ne": i.Viewer}})
+		}
+		if marker.MessageId != "" {
+			after, err := primitive.ObjectIDFromHex(marker.MessageId)
+			if err != nil {
+				return nil, errs.Invalid("markers.message_id", "message_id is not a message id")
+			}
+			filters[n] = append(filters[n], bson.M{"_id": bson.M{"$gt": after}})
+		}
+	}
+
+	defer mmw.ObserveDB("mongodb", "count_unread", time.Now())
+	counts := make(map[string]int32, len(i.Markers))
+	err = withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		for start := 0; start < len(i.Markers); start += maxUnreadRooms {
+			end := start + maxUnreadRooms
+			if end > len(i.Markers) {
+				end = len(i.Markers)
+			}
+			if err := countUnreadChunk(ctx, collection, i.Markers[start:end], filters[start:end], counts); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		mmw.LogError(log, "Error during unread counting", err)
+		return nil, errs.Database(err)
+	}
+
+	toReturn := make([]*grpcconnector.RoomUnread, len(i.Markers))
+	for n, marker := range i.Markers {
+		toReturn[n] = &grpcconnector.RoomUnread{Room: marker.Room, Unread: counts[marker.Room]}
+	}
+	return &grpcconnector.CountUnreadResponse{Results: toReturn}, nil
+}
+
+// Pipeline, that counts messages of the room up to maxUnreadCount, the result has room and count
+func unreadPipeline(room string, filter bson.A) bson.A {
+	return bson.A{
+		bson.D{{Key: "$match", Value: bson.M{"$and": filter}}},
+		bson.D{{Key: "$limit", Value: maxUnreadCount}},
+		bson.D{{Key: "$count", Value: "count"}},
+		bson.D{{Key: "$addFields", Value: bson.M{"room": bson.M{"$literal": room}}}},
+	}
+}
+
+// Counts unread messages of rooms with one aggregation, rooms without unread messages are left out of counts
+func countUnreadChunk(ctx context.Context, collection *mongo.Collection, markers []*grpcconnector.RoomMarker, filters []bson.A, counts map[string]int32) error {
+	pipeline := unreadPipeline(markers[0].Room, filters[0])
+	for n := 1; n < len(markers); n++ {
+		union := bson.M{"coll": collection.Name(), "pipeline": unreadPipeline(markers[n].Room, filters[n])}
+		pipeline = append(pipeline, bson.D{{Key: "$unionWith", Value: union}})
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	var results []struct {
+		Room  string `bson:"room"`
+		Count int32  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return err
+	}
+	for _, r := range results {
+		counts[r.Room] = r.Count
+	}
+	return nil
+}
@@ -1,25 +1,27 @@
 // Implements Write function
 // Everytime we establish new connection to database. TODO: remove this feature
 
-package main
+package mongoservice
 
 import (
+	mmw "chat_room_go/microservices"
+	"chat_room_go/microservices/events"
 	grpcconnector "chat_room_go/microservices/mongodb/pb"
 	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
 	"context"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
-	"go.mongodb.org/mongo-driver/mongo/readpref"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 var dbURL string = config.Config.MongoAdapter.DbURL
 
+// Room of messages without room
+var defaultRoom string = config.Config.MongoAdapter.DefaultRoom
+
 //cacheSize int    = 20
 
 // TODO: Add chache
@@ -38,67 +40,57 @@ type RPCWriter struct{}
 
 // grpc Write implementation
 func (w RPCWriter) Write(ctx context.Context, i *grpcconnector.WriteRequest) (*grpcconnector.WriteResponse, error) {
-	logger.Info(ctx, i)
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "Metadata was not found"}, status.Errorf(codes.NotFound, "Metadata was not found")
-	}
-	dbNames, ok := md["dbname"]
-	if !ok || len(dbNames) != 1 {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "dbName is not supplied"}, status.Errorf(codes.NotFound, "dbName is not supplied")
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	dbName, err := mmw.RequiredMetadata(ctx, "dbname")
+	if err != nil {
+		return nil, err
 	}
-	dbName := dbNames[0]
-	collectionNames, ok := md["collectionname"]
-	if !ok || len(collectionNames) != 1 {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "collection name is not supplied"}, status.Errorf(codes.NotFound, "collection name is not supplied")
+	collectionName, err := mmw.RequiredMetadata(ctx, "collectionname")
+	if err != nil {
+		return nil, err
 	}
-	collectionName := collectionNames[0]
 
-	err := writeToDB(dbName, collectionName, i)
+	toReturn, err := writeToDB(ctx, dbName, collectionName, i)
 	if err != nil {
-		logger.Errorf("Error during table insertion \"%s\"", err)
-		return &grpcconnector.WriteResponse{Status: 500, Desription: "Error during table insertion"}, status.Errorf(codes.NotFound, "Error during table insertion: %s", err)
+		mmw.LogError(log, "Error during table insertion", err)
+		return nil, errs.Database(err)
 	}
 
-	logger.Info("Response ok")
-	return &grpcconnector.WriteResponse{Status: 0, Desription: "Ok"}, nil
-}
-
-// Writes message to mongo
-func writeToDB(dbName, collectionName string, i *grpcconnector.WriteRequest) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	events.Publish(ctx, events.MessageCreated, messageEvent(toReturn))
 
-	// Creates connection
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dbURL))
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err = client.Disconnect(ctx); err != nil {
-			panic(err)
-		}
-	}()
-	defer func() {
-		if r := recover(); r != nil {
-			logger.Errorf("Recovered in writeToDB", r)
-		}
-	}()
+	log.Info("Response ok")
+	return &grpcconnector.WriteResponse{Result: toReturn}, nil
+}
 
-	// Checking connection
-	err = client.Ping(ctx, readpref.Primary())
-	if err != nil {
-		return err
+// Writes message to mongo, returns it with id
+func writeToDB(ctx context.Context, dbName, collectionName string, i *grpcconnector.WriteRequest) (*grpcconnector.MessageInfo, error) {
+	defer mmw.ObserveDB("mongodb", "insert", time.Now())
+	log := logs.With(ctx, logger)
+	doc := messageDoc{Time: i.Time, Name: i.Name, Message: i.Message, Room: i.Room, Bot: i.Bot, Nick: i.Nick}
+	if doc.Room == "" {
+		doc.Room = defaultRoom
 	}
 
 	// Retrieve collection and write to it
-	collection := client.Database(dbName).Collection(collectionName)
-	res, err := collection.InsertOne(ctx, bson.D{{"time", i.Time}, {"name", i.Name}, {"message", i.Message}})
+	err := withCollection(ctx, dbName, collectionName, func(ctx context.Context, collection *mongo.Collection) error {
+		if i.Parent != "" {
+			var err error
+			if doc.Parent, err = threadOf(ctx, collection, i.Parent, doc.Room); err != nil {
+				return err
+			}
+		}
+		res, err := collection.InsertOne(ctx, doc)
+		if err != nil {
+			return err
+		}
+		doc.ID = res.InsertedID.(primitive.ObjectID)
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	id := res.InsertedID
-	logger.Info(id)
+	log.Info(doc.ID)
 
-	return nil
+	return doc.info(), nil
 }
@@ -0,0 +1,341 @@
+// Implements Admin service: users and sessions management for chatctl
+// Users are hashes keyed by login, they are found by SCAN, sessions are found by their sorted sets
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+type RPCAdmin struct{}
+
+// Roles of users, main gives admin pages to "admin"
+var roles = []string{"user", "admin"}
+
+// KEYS: user; ARGV: fields and values of the user. Writes the user only if the login is free
+var createUserScript = redis.NewScript(1, `
+if redis.call("EXISTS", KEYS[1]) == 1 then return 0 end
+redis.call("HSET", KEYS[1], unpack(ARGV))
+return 1
+`)
+
+// grpc ListUsers implementation, password hashes are not returned
+func (w RPCAdmin) ListUsers(ctx context.Context, i *grpcconnector.ListUsersRequest) (*grpcconnector.ListUsersResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	toReturn, err := listUsersFromDB(ctx, i.Prefix)
+	if err != nil {
+		mmw.LogError(log, "Error during users listing", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.ListUsersResponse{Results: toReturn}, nil
+}
+
+// grpc CreateUser implementation
+func (w RPCAdmin) CreateUser(ctx context.Context, i *grpcconnector.WriteRequest) (*grpcconnector.SetUserResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i.Login)
+	switch {
+	case i.Login == "":
+		return nil, errs.Invalid("Login", "Login is not supplied")
+	case i.Pass == "":
+		return nil, errs.Invalid("Pass", "Pass is not supplied")
+	case !validRole(i.Role):
+		return nil, errs.Invalid("Role", "Role must be user or admin")
+	}
+	created, err := createUserInDB(ctx, i)
+	if err != nil {
+		mmw.LogError(log, "Error during user creation", err)
+		return nil, errs.Database(err)
+	}
+	if !created {
+		return nil, errs.New(errs.AlreadyExists, errs.ReasonUserExists, "User already exists")
+	}
+
+	return &grpcconnector.SetUserResponse{}, nil
+}
+
+// grpc SetRole implementation
+func (w RPCAdmin) SetRole(ctx context.Context, i *grpcconnector.SetRoleRequest) (*grpcconnector.SetUserResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	if !validRole(i.Role) {
+		return nil, errs.Invalid("Role", "Role must be user or admin")
+	}
+	return setUserField(ctx, i.Login, "Role", i.Role)
+}
+
+// grpc SetPassword implementation
+func (w RPCAdmin) SetPassword(ctx context.Context, i *grpcconnector.SetPasswordRequest) (*grpcconnector.SetUserResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	if i.Pass == "" {
+		return nil, errs.Invalid("Pass", "Pass is not supplied")
+	}
+	return setUserField(ctx, i.Login, "Pass", i.Pass)
+}
+
+// grpc SetDisabled implementation, disabled user also loses all sessions
+func (w RPCAdmin) SetDisabled(ctx context.Context, i *grpcconnector.SetDisabledRequest) (*grpcconnector.SetUserResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	if !i.Disabled {
+		return setUserField(ctx, i.Login, "Disabled", "")
+	}
+	resp, err := setUserField(ctx, i.Login, "Disabled", "1")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := killSessionsInDB(ctx, i.Login); err != nil {
+		mmw.LogError(log, "Error during sessions deletion", err)
+		return nil, errs.Database(err)
+	}
+
+	return resp, nil
+}
+
+// grpc ListSessions implementation
+func (w RPCAdmin) ListSessions(ctx context.Context, i *grpcconnector.ListSessionsRequest) (*grpcconnector.ListSessionsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	toReturn, err := listSessionsFromDB(ctx, i.Login)
+	if err != nil {
+		mmw.LogError(log, "Error during sessions listing", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.ListSessionsResponse{Results: toReturn}, nil
+}
+
+// grpc KillSessions implementation
+func (w RPCAdmin) KillSessions(ctx context.Context, i *grpcconnector.KillSessionsRequest) (*grpcconnector.KillSessionsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	if i.Login == "" {
+		return nil, errs.Invalid("Login", "Login is not supplied")
+	}
+	killed, err := killSessionsInDB(ctx, i.Login)
+	if err != nil {
+		mmw.LogError(log, "Error during sessions deletion", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.KillSessionsResponse{Killed: int32(killed)}, nil
+}
+
+func validRole(role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Writes the user, false if the login is taken
+func createUserInDB(ctx context.Context, i *grpcconnector.WriteRequest) (bool, error) {
+	defer mmw.ObserveDB("redis", "create_user", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	return redis.Bool(createUserScript.Do(conn, redis.Args{}.Add(i.Login).AddFlat(i)...))
+}
+
+// Sets field of existing user, empty value removes the field
+func setUserField(ctx context.Context, login, field, value string) (*grpcconnector.SetUserResponse, error) {
+	log := logs.With(ctx, logger)
+	found, err := setUserFieldInDB(ctx, login, field, value)
+	if err != nil {
+		mmw.LogError(log, "Error during user update", err)
+		return nil, errs.Database(err)
+	}
+	if !found {
+		return nil, errs.New(errs.NotFound, errs.ReasonUserNotFound, "User not found")
+	}
+
+	return &grpcconnector.SetUserResponse{}, nil
+}
+
+// Sets field of the user hash, false if there is no such user
+func setUserFieldInDB(ctx context.Context, login, field, value string) (bool, error) {
+	defer mmw.ObserveDB("redis", "set_user", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	exists, err := redis.Bool(conn.Do("HEXISTS", login, "Login"))
+	if err != nil || !exists {
+		return false, err
+	}
+	if value == "" {
+		_, err = conn.Do("HDEL", login, field)
+	} else {
+		_, err = conn.Do("HSET", login, field, value)
+	}
+	return err == nil, err
+}
+
+// Calls fn for every key of the type, matching the pattern
+func scanKeys(conn redis.Conn, pattern, keyType string, fn func(key string) error) error {
+	cursor := 0
+	for {
+		values, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", pattern, "TYPE", keyType, "COUNT", 1000))
+		if err != nil {
+			return err
+		}
+		var keys []string
+		if _, err := redis.Scan(values, &cursor, &keys); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := fn(k); err != nil {
+				return err
+			}
+		}
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// Returns users with login starting with prefix, sorted by login
+// Other hashes (tokens, hooks) have their own prefixes, user hash is the one keyed by its Login field
+func listUsersFromDB(ctx context.Context, prefix string) ([]*grpcconnector.UserInfo, error) {
+	defer mmw.ObserveDB("redis", "list_users", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var toReturn []*grpcconnector.UserInfo
+	err = scanKeys(conn, redisPattern(prefix)+"*", "hash", func(key string) error {
+		values, err := redis.Values(conn.Do("HGETALL", key))
+		if err != nil {
+			return err
+		}
+		u := &grpcconnector.UserInfo{}
+		redis.ScanStruct(values, u)
+		if u.Login == key {
+			u.Pass = ""
+			toReturn = append(toReturn, u)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(toReturn, func(a, b int) bool { return toReturn[a].Login < toReturn[b].Login })
+
+	return toReturn, nil
+}
+
+// Returns sessions of the user, of all users if login is empty
+func listSessionsFromDB(ctx context.Context, login string) ([]*grpcconnector.SessionInfo, error) {
+	defer mmw.ObserveDB("redis", "list_sessions", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	key := sessionsKey
+	if login != "" {
+		key = userSessionsKey(login)
+	}
+	conn.Send("MULTI")
+	conn.Send("ZREMRANGEBYSCORE", key, "-inf", time.Now().Unix())
+	conn.Send("ZRANGE", key, 0, -1)
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return nil, err
+	}
+	ids, err := redis.Strings(values[1], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var toReturn []*grpcconnector.SessionInfo
+	for _, id := range ids {
+		conn.Send("GET", sessionKey(id))
+		conn.Send("TTL", sessionKey(id))
+	}
+	conn.Flush()
+	for _, id := range ids {
+		owner, err := redis.String(conn.Receive())
+		ttl, ttlErr := redis.Int64(conn.Receive())
+		// Session expired or was deleted after listing
+		if err == redis.ErrNil {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if ttlErr != nil {
+			return nil, ttlErr
+		}
+		toReturn = append(toReturn, &grpcconnector.SessionInfo{Id: id, Login: owner, TTL: ttl})
+	}
+	sort.Slice(toReturn, func(a, b int) bool {
+		if toReturn[a].Login != toReturn[b].Login {
+			return toReturn[a].Login < toReturn[b].Login
+		}
+		return toReturn[a].Id < toReturn[b].Id
+	})
+
+	return toReturn, nil
+}
+
+// Deletes all sessions of the user, returns number of deleted ones
+func killSessionsInDB(ctx context.Context, login string) (int, error) {
+	sessions, err := listSessionsFromDB(ctx, login)
+	if err != nil || len(sessions) == 0 {
+		return 0, err
+	}
+	defer mmw.ObserveDB("redis", "kill_sessions", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	keys, ids := redis.Args{}, redis.Args{}
+	for _, s := range sessions {
+		keys, ids = keys.Add(sessionKey(s.Id)), ids.Add(s.Id)
+	}
+	conn.Send("MULTI")
+	conn.Send("DEL", keys...)
+	conn.Send("DEL", userSessionsKey(login))
+	conn.Send("ZREM", redis.Args{}.Add(sessionsKey).AddFlat(ids)...)
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, err
+	}
+	return redis.Int(values[0], nil)
+}
+
+// Escapes glob characters of SCAN pattern
+func redisPattern(s string) string {
+	escaped := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, r)
+	}
+	return string(escaped)
+}
@@ -4,6 +4,8 @@ package main
 
 import (
 	"chat_room_go/utils/logs"
+	"chat_room_go/utils/tracing"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -13,52 +15,61 @@ import (
 
 	mmw "chat_room_go/microservices"
 
-	grpcconnector "chat_room_go/microservices/redis/pb"
+	redisservice "chat_room_go/microservices/redis"
 
 	config "chat_room_go/utils/conf"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
-var logger *zap.SugaredLogger
-var wl logs.WriterToClickHouse
-
-func init() {
-	logger = logs.InitDirLogger(config.Config.RedisAdapter.PathToLogs)
-}
-
 // Listen and serve grpc
 func main() {
-	defer logger.Sync()
-	defer wl.GrpcConn.Close()
-
 	creds, err := loadTLSCredentials()
 	if err != nil {
-		logger.Fatal("cannot load TLS credentials: ", err)
+		log.Fatal("cannot load TLS credentials: ", err)
+	}
+	shutdownTracing, err := tracing.Init("chat-redis")
+	if err != nil {
+		log.Fatal("cannot init tracing: ", err)
 	}
 	mmw.TokenAuth = config.Config.RedisAdapter.TokenAuth
 	server := grpc.NewServer(
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(mmw.LogInterceptor, mmw.AuthInterceptor)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(mmw.TracingInterceptor, mmw.LogInterceptor, mmw.MetricsInterceptor, mmw.AuthInterceptor, mmw.ErrorInterceptor)),
 		grpc.InTapHandle(mmw.RateLimiter),
 	)
-	grpcconnector.RegisterWriterServer(server, RPCWriter{})
-	grpcconnector.RegisterReaderServer(server, RPCReader{})
-	grpcconnector.RegisterGetterSessionServer(server, RPCReader{})
-	grpcconnector.RegisterWriterSessionServer(server, RPCWriter{})
+	// Background work of the service: health checks
+	background, stopBackground := context.WithCancel(context.Background())
+	redisservice.Register(background, server)
 
 	lis, err := net.Listen("tcp", config.Config.RedisAdapter.IntURL)
 	if err != nil {
 		log.Fatalln("cant listen port", err)
 	}
-	defer lis.Close()
 
 	fmt.Println("starting server at ", config.Config.RedisAdapter.IntURL)
-	logger.Debugf("Recieved request")
-	server.Serve(lis)
+	metricsServer := mmw.ServeMetrics(config.Config.RedisAdapter.MetricsURL)
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			log.Println("server stopped: ", err)
+		}
+	}()
+
+	sig := mmw.WaitForSignal()
+	fmt.Println("received ", sig, ", shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), config.Config.ShutdownDuration())
+	defer cancel()
+	metricsServer.Shutdown(ctx)
+	defer shutdownTracing(ctx)
+	// Health turns NOT_SERVING, so probes stop sending requests
+	stopBackground()
+	mmw.GracefulStop(ctx, server)
+	if err := redisservice.Close(); err != nil {
+		log.Println("error during cleanup: ", err)
+	}
+	logs.Logger.Sync()
 }
 
 // Establish TLS
@@ -0,0 +1,298 @@
+// Delivery of events to outgoing webhooks
+// Worker moves events from queue to processing list, fans them out to subscribed hooks into retry sorted set
+// and only then removes them from processing list, so an event is not lost when worker stops in between.
+// Deliveries are POSTed signed, failed ones wait in retry sorted set with exponential backoff,
+// after the last attempt they go to dead-letter list of the hook owner
+
+package redisservice
+
+import (
+	"bytes"
+	"chat_room_go/microservices/events"
+	config "chat_room_go/utils/conf"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Headers of delivery
+const (
+	eventHeader     = "X-Chat-Event"
+	deliveryHeader  = "X-Chat-Delivery"
+	signatureHeader = "X-Chat-Signature"
+)
+
+var deliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "chat_webhook_deliveries_total",
+	Help: "Number of outgoing webhook delivery attempts by result: ok, retry, dead",
+}, []string{"result"})
+
+// One event for one hook, Attempt is number of failed attempts
+type delivery struct {
+	ID        string          `json:"id"`
+	HookID    string          `json:"hook_id"`
+	Login     string          `json:"login"`
+	EventType string          `json:"event_type"`
+	Event     json.RawMessage `json:"event"`
+	Attempt   int             `json:"attempt"`
+	Error     string          `json:"error,omitempty"`
+	FailedAt  string          `json:"failed_at,omitempty"`
+}
+
+// Worker of deliveries, started by Register, stopped by Close
+type deliveryWorker struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// Limits concurrent POSTs
+	slots  chan struct{}
+	client *http.Client
+}
+
+var worker *deliveryWorker
+
+// Starts delivery worker
+func startDelivery() {
+	conf := config.Config.Webhooks
+	workers := conf.OutgoingWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	worker = &deliveryWorker{
+		cancel: cancel,
+		slots:  make(chan struct{}, workers),
+		client: hookClient(time.Duration(conf.OutgoingTimeout) * time.Second),
+	}
+	if err := requeueProcessing(); err != nil {
+		logger.Errorf("Error during requeue of webhook events \"%s\"", err)
+	}
+	worker.wg.Add(1)
+	go worker.run(ctx)
+}
+
+// Returns events, left in processing list by stopped worker, to queue
+func requeueProcessing() error {
+	conn := pool.Get()
+	defer conn.Close()
+	for {
+		_, err := redis.Bytes(conn.Do("RPOPLPUSH", events.ProcessingKey, events.QueueKey))
+		if err == redis.ErrNil {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// Stops delivery worker, waits for started deliveries
+func stopDelivery() {
+	if worker == nil {
+		return
+	}
+	worker.cancel()
+	worker.wg.Wait()
+}
+
+func (w *deliveryWorker) run(ctx context.Context) {
+	defer w.wg.Done()
+	for ctx.Err() == nil {
+		if err := w.takeRetries(ctx); err != nil {
+			logger.Errorf("Error during webhook retries reading \"%s\"", err)
+		}
+		if err := w.takeEvent(ctx); err != nil {
+			logger.Errorf("Error during webhook queue reading \"%s\"", err)
+			// Redis is down, do not spin
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+// Waits for new event up to a second, fans it out to subscribed hooks
+func (w *deliveryWorker) takeEvent(ctx context.Context) error {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	raw, err := redis.Bytes(redis.DoWithTimeout(conn, 2*time.Second, "BRPOPLPUSH", events.QueueKey, events.ProcessingKey, 1))
+	if err == redis.ErrNil {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var event events.Event
+	if err := json.Unmarshal(raw, &event); err != nil {
+		logger.Errorf("Skipping malformed event \"%s\"", err)
+		_, err = conn.Do("LREM", events.ProcessingKey, 1, raw)
+		return err
+	}
+	hooks, err := listOutHooksFromDB(ctx, outHooksKey)
+	if err != nil {
+		// Event goes back to queue to be taken again
+		conn.Send("MULTI")
+		conn.Send("LREM", events.ProcessingKey, 1, raw)
+		conn.Send("RPUSH", events.QueueKey, raw)
+		if _, rerr := conn.Do("EXEC"); rerr != nil {
+			logger.Errorf("Error during requeue of webhook event \"%s\"", rerr)
+		}
+		return err
+	}
+
+	// Deliveries are due now, event is acknowledged in the same transaction they are written
+	now := time.Now().Unix()
+	conn.Send("MULTI")
+	for _, h := range hooks {
+		if h.subscribed(event.Type) {
+			v, _ := json.Marshal(&delivery{ID: events.NewID(), HookID: h.Id, Login: h.Login, EventType: event.Type, Event: raw})
+			conn.Send("ZADD", events.RetryKey, now, v)
+		}
+	}
+	conn.Send("LREM", events.ProcessingKey, 1, raw)
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+// Takes deliveries, that are due: new ones and retries
+func (w *deliveryWorker) takeRetries(ctx context.Context) error {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	due, err := redis.ByteSlices(conn.Do("ZRANGEBYSCORE", events.RetryKey, "-inf", time.Now().Unix(), "LIMIT", 0, 100))
+	if err != nil {
+		return err
+	}
+	for _, v := range due {
+		// Several workers may see the same delivery, the one that removed it delivers
+		removed, err := redis.Int(conn.Do("ZREM", events.RetryKey, v))
+		if err != nil {
+			return err
+		}
+		d := &delivery{}
+		if removed == 0 || json.Unmarshal(v, d) != nil {
+			continue
+		}
+		w.start(ctx, d)
+	}
+	return nil
+}
+
+// Delivers in background, blocks while all slots are busy
+func (w *deliveryWorker) start(ctx context.Context, d *delivery) {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		// Stopping, delivery waits for the next start
+		w.fail(d, "worker stopped", false)
+		return
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer func() { <-w.slots }()
+		w.deliver(d)
+	}()
+}
+
+// POSTs event to the hook, schedules retry or moves delivery to dead letters on failure
+func (w *deliveryWorker) deliver(d *delivery) {
+	conn := pool.Get()
+	hook, err := readOutHook(conn, d.HookID)
+	conn.Close()
+	if err != nil {
+		w.fail(d, err.Error(), false)
+		return
+	}
+	// Hook was deleted
+	if hook == nil {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(d.Event))
+	if err != nil {
+		w.fail(d, err.Error(), true)
+		return
+	}
+	mac := hmac.New(sha256.New, []byte(hook.SigningSecret))
+	mac.Write(d.Event)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(eventHeader, d.EventType)
+	req.Header.Set(deliveryHeader, d.ID)
+	req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		w.fail(d, err.Error(), true)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		w.fail(d, "endpoint answered "+strconv.Itoa(resp.StatusCode), true)
+		return
+	}
+	deliveries.WithLabelValues("ok").Inc()
+}
+
+// Schedules next attempt with exponential backoff, attempt counts only when endpoint failed (not redis or stopping)
+func (w *deliveryWorker) fail(d *delivery, reason string, attempted bool) {
+	conf := config.Config.Webhooks
+	if attempted {
+		d.Attempt++
+	}
+	d.Error = reason
+
+	conn := pool.Get()
+	defer conn.Close()
+	if d.Attempt >= conf.OutgoingMaxAttempts {
+		d.FailedAt = time.Now().Format("2006-01-02 15:04:05")
+		v, _ := json.Marshal(d)
+		conn.Send("MULTI")
+		conn.Send("LPUSH", events.DeadLettersKey(d.Login), v)
+		conn.Send("LTRIM", events.DeadLettersKey(d.Login), 0, conf.DeadLetterLimit-1)
+		if _, err := conn.Do("EXEC"); err != nil {
+			logger.Errorf("Error during dead letter writing \"%s\", delivery %s is lost", err, d.ID)
+		}
+		logger.Warnf("Delivery %s of %s to hook %s is dead after %d attempts: %s", d.ID, d.EventType, d.HookID, d.Attempt, reason)
+		deliveries.WithLabelValues("dead").Inc()
+		return
+	}
+
+	next := time.Now().Add(retryBackoff(d.Attempt))
+	v, _ := json.Marshal(d)
+	if _, err := conn.Do("ZADD", events.RetryKey, next.Unix(), v); err != nil {
+		logger.Errorf("Error during retry scheduling \"%s\", delivery %s is lost", err, d.ID)
+	}
+	logger.Infof("Delivery %s to hook %s failed (%s), retry at %s", d.ID, d.HookID, reason, next.Format(time.RFC3339))
+	deliveries.WithLabelValues("retry").Inc()
+}
+
+// Exponential backoff of attempt: base, 2*base, 4*base... capped by max
+func retryBackoff(attempt int) time.Duration {
+	conf := config.Config.Webhooks
+	base := time.Duration(conf.OutgoingBackoffBase) * time.Second
+	max := time.Duration(conf.OutgoingBackoffMax) * time.Second
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := base << uint(attempt-1)
+	if d <= 0 || d > max {
+		return max
+	}
+	return d
+}
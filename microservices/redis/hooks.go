@@ -0,0 +1,240 @@
+// Incoming webhooks: secret url lets integration post messages to the room as bot
+// Secret of url is returned once, redis stores only its sha256 hash:
+// "inhook:<hash>" - hash with hook info, "inhooks:<login>" - hash of hook id to hook hash
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Prefixes of hook url secrets and signing secrets
+const (
+	HookPrefix          = "crh_"
+	SigningSecretPrefix = "whsec_"
+)
+
+type RPCIncomingHooks struct{}
+
+// Hook as stored in redis
+type hookRecord struct {
+	Id            string
+	Login         string
+	Name          string
+	Room          string
+	SigningSecret string
+	CreatedAt     string
+}
+
+func (h *hookRecord) info() *grpcconnector.IncomingHookInfo {
+	return &grpcconnector.IncomingHookInfo{
+		Id:            h.Id,
+		Login:         h.Login,
+		Name:          h.Name,
+		Room:          h.Room,
+		SigningSecret: h.SigningSecret,
+		CreatedAt:     h.CreatedAt,
+	}
+}
+
+func hookKey(hash string) string {
+	return "inhook:" + hash
+}
+
+func userHooksKey(login string) string {
+	return "inhooks:" + login
+}
+
+// grpc CreateIncomingHook implementation
+func (h RPCIncomingHooks) CreateIncomingHook(ctx context.Context, i *grpcconnector.CreateIncomingHookRequest) (*grpcconnector.CreateIncomingHookResponse, error) {
+	log := logs.With(ctx, logger)
+	hook := i.GetHook()
+	switch {
+	case hook.GetLogin() == "":
+		return nil, errs.Invalid("hook.Login", "Login is required")
+	case hook.GetName() == "":
+		return nil, errs.Invalid("hook.Name", "Name is required")
+	case hook.GetRoom() == "":
+		return nil, errs.Invalid("hook.Room", "Room is required")
+	}
+	log.Infof("Creating incoming hook \"%s\" of \"%s\" to room \"%s\"", hook.Name, hook.Login, hook.Room)
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal, errs.ReasonInternal, "Error during hook generation", err)
+	}
+	secret, err := randomHex(20)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal, errs.ReasonInternal, "Error during hook generation", err)
+	}
+	secret = HookPrefix + secret
+	record := &hookRecord{
+		Id:        id,
+		Login:     hook.Login,
+		Name:      hook.Name,
+		Room:      hook.Room,
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	if i.Signed {
+		signing, err := randomHex(20)
+		if err != nil {
+			return nil, errs.Wrap(errs.Internal, errs.ReasonInternal, "Error during hook generation", err)
+		}
+		record.SigningSecret = SigningSecretPrefix + signing
+	}
+	err = writeHookToDB(ctx, hashSecret(secret), record)
+	if err != nil {
+		mmw.LogError(log, "Error during hook insertion", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.CreateIncomingHookResponse{Hook: record.info(), Secret: secret}, nil
+}
+
+// grpc GetIncomingHook implementation
+func (h RPCIncomingHooks) GetIncomingHook(ctx context.Context, i *grpcconnector.GetIncomingHookRequest) (*grpcconnector.IncomingHookInfo, error) {
+	log := logs.With(ctx, logger)
+	record, err := readHookFromDB(ctx, hashSecret(i.Secret))
+	if err != nil {
+		mmw.LogError(log, "Error during hook reading", err)
+		return nil, errs.Database(err)
+	}
+	if record == nil {
+		return nil, errs.New(errs.NotFound, errs.ReasonHookNotFound, "Hook not found")
+	}
+
+	return record.info(), nil
+}
+
+// grpc ListIncomingHooks implementation, hooks are sorted by creation time
+func (h RPCIncomingHooks) ListIncomingHooks(ctx context.Context, i *grpcconnector.ListIncomingHooksRequest) (*grpcconnector.ListIncomingHooksResponse, error) {
+	log := logs.With(ctx, logger)
+	records, err := listHooksFromDB(ctx, i.Login)
+	if err != nil {
+		mmw.LogError(log, "Error during hooks reading", err)
+		return nil, errs.Database(err)
+	}
+	results := make([]*grpcconnector.IncomingHookInfo, 0, len(records))
+	for _, r := range records {
+		results = append(results, r.info())
+	}
+
+	return &grpcconnector.ListIncomingHooksResponse{Results: results}, nil
+}
+
+// grpc DeleteIncomingHook implementation
+func (h RPCIncomingHooks) DeleteIncomingHook(ctx context.Context, i *grpcconnector.DeleteIncomingHookRequest) (*grpcconnector.DeleteIncomingHookResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Infof("Deleting incoming hook \"%s\" of \"%s\"", i.Id, i.Login)
+	found, err := deleteHookFromDB(ctx, i.Login, i.Id)
+	if err != nil {
+		mmw.LogError(log, "Error during hook deletion", err)
+		return nil, errs.Database(err)
+	}
+	if !found {
+		return nil, errs.New(errs.NotFound, errs.ReasonHookNotFound, "Hook not found")
+	}
+
+	return &grpcconnector.DeleteIncomingHookResponse{}, nil
+}
+
+// Writes hook and adds it to hooks of the user
+func writeHookToDB(ctx context.Context, hash string, record *hookRecord) error {
+	defer mmw.ObserveDB("redis", "create_hook", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("HSET", redis.Args{}.Add(hookKey(hash)).AddFlat(record)...)
+	conn.Send("HSET", userHooksKey(record.Login), record.Id, hash)
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+// Reads hook by hash of url secret, nil if there is no such hook
+func readHookFromDB(ctx context.Context, hash string) (*hookRecord, error) {
+	defer mmw.ObserveDB("redis", "get_hook", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return readHook(conn, hash)
+}
+
+func readHook(conn redis.Conn, hash string) (*hookRecord, error) {
+	values, err := redis.Values(conn.Do("HGETALL", hookKey(hash)))
+	if err != nil {
+		return nil, err
+	}
+	record := &hookRecord{}
+	if err := redis.ScanStruct(values, record); err != nil {
+		return nil, err
+	}
+	if record.Id == "" {
+		return nil, nil
+	}
+	return record, nil
+}
+
+// Reads all hooks of the user
+func listHooksFromDB(ctx context.Context, login string) ([]*hookRecord, error) {
+	defer mmw.ObserveDB("redis", "list_hooks", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	hashes, err := redis.StringMap(conn.Do("HGETALL", userHooksKey(login)))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*hookRecord, 0, len(hashes))
+	for _, hash := range hashes {
+		record, err := readHook(conn, hash)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(a, b int) bool { return records[a].CreatedAt < records[b].CreatedAt })
+
+	return records, nil
+}
+
+// Deletes hook of the user, false if user has no such hook
+func deleteHookFromDB(ctx context.Context, login, id string) (bool, error) {
+	defer mmw.ObserveDB("redis", "delete_hook", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	hash, err := redis.String(conn.Do("HGET", userHooksKey(login), id))
+	if err == redis.ErrNil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	conn.Send("MULTI")
+	conn.Send("DEL", hookKey(hash))
+	conn.Send("HDEL", userHooksKey(login), id)
+	_, err = conn.Do("EXEC")
+	return err == nil, err
+}
@@ -0,0 +1,346 @@
+// Implements Mentions service: inbox of mentions of every user, main adds mentions of posted messages
+// "mention:<id>" - hash with mention, "mentions:<login>" - sorted set of mention ids by time,
+// "unreadmentions:<login>" - set of unread mention ids. Inbox keeps the last maxInboxMentions mentions
+// "messagementions:<message id>" - set of ids of mentions in the message and in replies to it, they are deleted with the message
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Mentions kept in inbox of user, older ones are dropped
+const maxInboxMentions = 200
+
+type RPCMentions struct{}
+
+// Mention as stored in redis, read state is kept in the set of unread ones
+type mentionRecord struct {
+	Id        string
+	Login     string
+	MessageId string
+	Room      string
+	Author    string
+	Text      string
+	Time      string
+	Parent    string
+}
+
+func (m *mentionRecord) info(read bool) *grpcconnector.Mention {
+	return &grpcconnector.Mention{
+		Id:        m.Id,
+		Login:     m.Login,
+		MessageId: m.MessageId,
+		Room:      m.Room,
+		Author:    m.Author,
+		Text:      m.Text,
+		Time:      m.Time,
+		Parent:    m.Parent,
+		Read:      read,
+	}
+}
+
+func mentionKey(id string) string {
+	return "mention:" + id
+}
+
+func userMentionsKey(login string) string {
+	return "mentions:" + login
+}
+
+func unreadMentionsKey(login string) string {
+	return "unreadmentions:" + login
+}
+
+func messageMentionsKey(messageID string) string {
+	return "messagementions:" + messageID
+}
+
+// grpc AddMentions implementation, logins of missing users are skipped
+func (m RPCMentions) AddMentions(ctx context.Context, i *grpcconnector.AddMentionsRequest) (*grpcconnector.AddMentionsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	mention := i.GetMention()
+	if mention.GetMessageId() == "" {
+		return nil, errs.Invalid("mention.MessageId", "MessageId is required")
+	}
+	now := time.Now()
+	record := &mentionRecord{
+		MessageId: mention.MessageId,
+		Room:      mention.Room,
+		Author:    mention.Author,
+		Text:      mention.Text,
+		Time:      now.Format("2006-01-02 15:04:05"),
+		Parent:    mention.Parent,
+	}
+	added, err := addMentionsToDB(ctx, i.Logins, record, now)
+	if err != nil {
+		mmw.LogError(log, "Error during mentions insertion", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.AddMentionsResponse{Logins: added}, nil
+}
+
+// grpc ListMentions implementation
+func (m RPCMentions) ListMentions(ctx context.Context, i *grpcconnector.ListMentionsRequest) (*grpcconnector.ListMentionsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	if i.Login == "" {
+		return nil, errs.Invalid("Login", "Login is not supplied")
+	}
+	results, unread, err := listMentionsFromDB(ctx, i.Login, i.UnreadOnly, int(i.Number))
+	if err != nil {
+		mmw.LogError(log, "Error during mentions reading", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.ListMentionsResponse{Results: results, Unread: unread}, nil
+}
+
+// grpc CountMentions implementation
+func (m RPCMentions) CountMentions(ctx context.Context, i *grpcconnector.CountMentionsRequest) (*grpcconnector.CountMentionsResponse, error) {
+	log := logs.With(ctx, logger)
+	if i.Login == "" {
+		return nil, errs.Invalid("Login", "Login is not supplied")
+	}
+	unread, err := markMentionsInDB(ctx, i.Login, nil, false)
+	if err != nil {
+		mmw.LogError(log, "Error during mentions counting", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.CountMentionsResponse{Unread: unread}, nil
+}
+
+// grpc MarkMentionsRead implementation, returns number of mentions left unread
+func (m RPCMentions) MarkMentionsRead(ctx context.Context, i *grpcconnector.MarkMentionsReadRequest) (*grpcconnector.CountMentionsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	if i.Login == "" {
+		return nil, errs.Invalid("Login", "Login is not supplied")
+	}
+	unread, err := markMentionsInDB(ctx, i.Login, i.Ids, true)
+	if err != nil {
+		mmw.LogError(log, "Error during mentions update", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.CountMentionsResponse{Unread: unread}, nil
+}
+
+// grpc DeleteMentions implementation
+func (m RPCMentions) DeleteMentions(ctx context.Context, i *grpcconnector.DeleteMentionsRequest) (*grpcconnector.DeleteMentionsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	var deleted int64
+	for _, id := range i.MessageIds {
+		if id == "" {
+			return nil, errs.Invalid("MessageIds", "MessageIds must not be empty")
+		}
+		n, err := deleteMentionsFromDB(ctx, id)
+		if err != nil {
+			mmw.LogError(log, "Error during mentions deletion", err)
+			return nil, errs.Database(err)
+		}
+		deleted += n
+	}
+
+	return &grpcconnector.DeleteMentionsResponse{Deleted: deleted}, nil
+}
+
+// Adds copy of the mention to inbox of every existing user, returns logins of them
+func addMentionsToDB(ctx context.Context, logins []string, record *mentionRecord, now time.Time) ([]string, error) {
+	defer mmw.ObserveDB("redis", "add_mentions", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	score := now.UnixNano() / int64(time.Millisecond)
+	var added []string
+	for _, login := range logins {
+		exists, err := redis.Bool(conn.Do("HEXISTS", login, "Login"))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		id, err := randomHex(8)
+		if err != nil {
+			return nil, err
+		}
+		mention := *record
+		mention.Id, mention.Login = id, login
+
+		conn.Send("MULTI")
+		conn.Send("HSET", redis.Args{}.Add(mentionKey(id)).AddFlat(&mention)...)
+		conn.Send("ZADD", userMentionsKey(login), score, id)
+		conn.Send("SADD", unreadMentionsKey(login), id)
+		conn.Send("SADD", messageMentionsKey(mention.MessageId), id)
+		if mention.Parent != "" {
+			conn.Send("SADD", messageMentionsKey(mention.Parent), id)
+		}
+		if _, err := conn.Do("EXEC"); err != nil {
+			return nil, err
+		}
+		if err := trimInbox(conn, login); err != nil {
+			return nil, err
+		}
+		added = append(added, login)
+	}
+	return added, nil
+}
+
+// Drops the oldest mentions of the user over maxInboxMentions
+func trimInbox(conn redis.Conn, login string) error {
+	count, err := redis.Int(conn.Do("ZCARD", userMentionsKey(login)))
+	if err != nil || count <= maxInboxMentions {
+		return err
+	}
+	ids, err := redis.Strings(conn.Do("ZRANGE", userMentionsKey(login), 0, count-maxInboxMentions-1))
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	if _, err := removeMentions(conn, ids); err != nil {
+		return err
+	}
+	// Ids without hash are not removed by removeMentions, inbox must shrink anyway
+	conn.Send("ZREM", redis.Args{}.Add(userMentionsKey(login)).AddFlat(ids)...)
+	_, err = conn.Do("SREM", redis.Args{}.Add(unreadMentionsKey(login)).AddFlat(ids)...)
+	return err
+}
+
+// Removes mentions from inboxes and from sets of their messages, mentions already removed are skipped, returns number of removed ones
+func removeMentions(conn redis.Conn, ids []string) (int64, error) {
+	for _, id := range ids {
+		conn.Send("HMGET", mentionKey(id), "Login", "MessageId", "Parent")
+	}
+	if err := conn.Flush(); err != nil {
+		return 0, err
+	}
+	records := make([][]string, len(ids))
+	for n := range ids {
+		values, err := redis.Strings(conn.Receive())
+		if err != nil {
+			return 0, err
+		}
+		records[n] = values
+	}
+
+	var removed int64
+	conn.Send("MULTI")
+	for n, id := range ids {
+		login, messageID, parent := records[n][0], records[n][1], records[n][2]
+		if login == "" {
+			continue
+		}
+		conn.Send("DEL", mentionKey(id))
+		conn.Send("ZREM", userMentionsKey(login), id)
+		conn.Send("SREM", unreadMentionsKey(login), id)
+		conn.Send("SREM", messageMentionsKey(messageID), id)
+		if parent != "" {
+			conn.Send("SREM", messageMentionsKey(parent), id)
+		}
+		removed++
+	}
+	_, err := conn.Do("EXEC")
+	return removed, err
+}
+
+// Deletes mentions in the message and in replies to it, returns number of deleted ones
+func deleteMentionsFromDB(ctx context.Context, messageID string) (int64, error) {
+	defer mmw.ObserveDB("redis", "delete_mentions", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	ids, err := redis.Strings(conn.Do("SMEMBERS", messageMentionsKey(messageID)))
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	deleted, err := removeMentions(conn, ids)
+	if err != nil {
+		return 0, err
+	}
+	_, err = conn.Do("DEL", messageMentionsKey(messageID))
+	return deleted, err
+}
+
+// Reads mentions of the user from the newest one, returns them with number of unread ones
+func listMentionsFromDB(ctx context.Context, login string, unreadOnly bool, number int) ([]*grpcconnector.Mention, int64, error) {
+	defer mmw.ObserveDB("redis", "list_mentions", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Close()
+
+	ids, err := redis.Strings(conn.Do("ZREVRANGE", userMentionsKey(login), 0, -1))
+	if err != nil {
+		return nil, 0, err
+	}
+	unreadIds, err := redis.Strings(conn.Do("SMEMBERS", unreadMentionsKey(login)))
+	if err != nil {
+		return nil, 0, err
+	}
+	unread := make(map[string]bool, len(unreadIds))
+	for _, id := range unreadIds {
+		unread[id] = true
+	}
+
+	var results []*grpcconnector.Mention
+	for _, id := range ids {
+		if number > 0 && len(results) >= number {
+			break
+		}
+		if unreadOnly && !unread[id] {
+			continue
+		}
+		values, err := redis.Values(conn.Do("HGETALL", mentionKey(id)))
+		if err != nil {
+			return nil, 0, err
+		}
+		record := &mentionRecord{}
+		if err := redis.ScanStruct(values, record); err != nil {
+			return nil, 0, err
+		}
+		if record.Id != "" {
+			results = append(results, record.info(!unread[id]))
+		}
+	}
+	return results, int64(len(unreadIds)), nil
+}
+
+// Marks mentions of the user read (all of them if ids are empty) when read is set, returns number of unread ones
+func markMentionsInDB(ctx context.Context, login string, ids []string, read bool) (int64, error) {
+	defer mmw.ObserveDB("redis", "mark_mentions", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if read {
+		if len(ids) == 0 {
+			_, err = conn.Do("DEL", unreadMentionsKey(login))
+		} else {
+			_, err = conn.Do("SREM", redis.Args{}.Add(unreadMentionsKey(login)).AddFlat(ids)...)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return redis.Int64(conn.Do("SCARD", unreadMentionsKey(login)))
+}
@@ -0,0 +1,287 @@
+// Outgoing webhooks: endpoints, that receive events of write paths (see microservices/events)
+// "outhook:<id>" - hash with hook info, "outhooks" - set of all hook ids, "outhooks:<login>" - set of hook ids of user
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	"chat_room_go/microservices/events"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Key of set of all outgoing hooks
+const outHooksKey = "outhooks"
+
+type RPCOutgoingHooks struct{}
+
+// Outgoing hook as stored in redis, events are joined by space
+type outHookRecord struct {
+	Id            string
+	Login         string
+	URL           string
+	Events        string
+	SigningSecret string
+	CreatedAt     string
+}
+
+func (h *outHookRecord) info() *grpcconnector.OutgoingHookInfo {
+	return &grpcconnector.OutgoingHookInfo{
+		Id:            h.Id,
+		Login:         h.Login,
+		URL:           h.URL,
+		Events:        strings.Fields(h.Events),
+		SigningSecret: h.SigningSecret,
+		CreatedAt:     h.CreatedAt,
+	}
+}
+
+// Tells if hook is subscribed to events of the type
+func (h *outHookRecord) subscribed(typ string) bool {
+	for _, e := range strings.Fields(h.Events) {
+		if e == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func outHookKey(id string) string {
+	return "outhook:" + id
+}
+
+func userOutHooksKey(login string) string {
+	return "outhooks:" + login
+}
+
+// Checks url and event types of new hook, host of url must have public addresses only
+func validateOutHook(ctx context.Context, hook *grpcconnector.OutgoingHookInfo) error {
+	if hook.GetLogin() == "" {
+		return errs.Invalid("hook.Login", "Login is required")
+	}
+	u, err := url.Parse(hook.GetURL())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errs.Invalid("hook.URL", "URL must be absolute http or https url")
+	}
+	if err := checkHookHost(ctx, u.Hostname()); err != nil {
+		return errs.Invalid("hook.URL", "Host of URL must resolve to public addresses only: "+err.Error())
+	}
+	if len(hook.GetEvents()) == 0 {
+		return errs.Invalid("hook.Events", "At least one event type is required")
+	}
+	for _, e := range hook.Events {
+		known := false
+		for _, t := range events.Types {
+			known = known || e == t
+		}
+		if !known {
+			return errs.Invalid("hook.Events", "Unknown event type \""+e+"\", allowed: "+strings.Join(events.Types, ", "))
+		}
+	}
+	return nil
+}
+
+// grpc CreateOutgoingHook implementation
+func (h RPCOutgoingHooks) CreateOutgoingHook(ctx context.Context, i *grpcconnector.CreateOutgoingHookRequest) (*grpcconnector.OutgoingHookInfo, error) {
+	log := logs.With(ctx, logger)
+	hook := i.GetHook()
+	if err := validateOutHook(ctx, hook); err != nil {
+		return nil, err
+	}
+	log.Infof("Creating outgoing hook of \"%s\" to \"%s\"", hook.Login, hook.URL)
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal, errs.ReasonInternal, "Error during hook generation", err)
+	}
+	signing, err := randomHex(20)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal, errs.ReasonInternal, "Error during hook generation", err)
+	}
+	record := &outHookRecord{
+		Id:            id,
+		Login:         hook.Login,
+		URL:           hook.URL,
+		Events:        strings.Join(hook.Events, " "),
+		SigningSecret: SigningSecretPrefix + signing,
+		CreatedAt:     time.Now().Format("2006-01-02 15:04:05"),
+	}
+	err = writeOutHookToDB(ctx, record)
+	if err != nil {
+		mmw.LogError(log, "Error during hook insertion", err)
+		return nil, errs.Database(err)
+	}
+
+	return record.info(), nil
+}
+
+// grpc ListOutgoingHooks implementation, hooks are sorted by creation time
+func (h RPCOutgoingHooks) ListOutgoingHooks(ctx context.Context, i *grpcconnector.ListOutgoingHooksRequest) (*grpcconnector.ListOutgoingHooksResponse, error) {
+	log := logs.With(ctx, logger)
+	records, err := listOutHooksFromDB(ctx, userOutHooksKey(i.Login))
+	if err != nil {
+		mmw.LogError(log, "Error during hooks reading", err)
+		return nil, errs.Database(err)
+	}
+	results := make([]*grpcconnector.OutgoingHookInfo, 0, len(records))
+	for _, r := range records {
+		results = append(results, r.info())
+	}
+
+	return &grpcconnector.ListOutgoingHooksResponse{Results: results}, nil
+}
+
+// grpc DeleteOutgoingHook implementation
+func (h RPCOutgoingHooks) DeleteOutgoingHook(ctx context.Context, i *grpcconnector.DeleteOutgoingHookRequest) (*grpcconnector.DeleteOutgoingHookResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Infof("Deleting outgoing hook \"%s\" of \"%s\"", i.Id, i.Login)
+	found, err := deleteOutHookFromDB(ctx, i.Login, i.Id)
+	if err != nil {
+		mmw.LogError(log, "Error during hook deletion", err)
+		return nil, errs.Database(err)
+	}
+	if !found {
+		return nil, errs.New(errs.NotFound, errs.ReasonHookNotFound, "Hook not found")
+	}
+
+	return &grpcconnector.DeleteOutgoingHookResponse{}, nil
+}
+
+// grpc ListDeadLetters implementation, newest first
+func (h RPCOutgoingHooks) ListDeadLetters(ctx context.Context, i *grpcconnector.ListDeadLettersRequest) (*grpcconnector.ListDeadLettersResponse, error) {
+	log := logs.With(ctx, logger)
+	letters, err := listDeadLettersFromDB(ctx, i.Login)
+	if err != nil {
+		mmw.LogError(log, "Error during dead letters reading", err)
+		return nil, errs.Database(err)
+	}
+	results := make([]*grpcconnector.DeadLetter, 0, len(letters))
+	for _, d := range letters {
+		results = append(results, &grpcconnector.DeadLetter{
+			Id:        d.ID,
+			HookId:    d.HookID,
+			EventType: d.EventType,
+			Event:     string(d.Event),
+			Attempts:  int32(d.Attempt),
+			Error:     d.Error,
+			FailedAt:  d.FailedAt,
+		})
+	}
+
+	return &grpcconnector.ListDeadLettersResponse{Results: results}, nil
+}
+
+// Writes hook and adds it to sets of all hooks and hooks of the user
+func writeOutHookToDB(ctx context.Context, record *outHookRecord) error {
+	defer mmw.ObserveDB("redis", "create_outhook", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("HSET", redis.Args{}.Add(outHookKey(record.Id)).AddFlat(record)...)
+	conn.Send("SADD", outHooksKey, record.Id)
+	conn.Send("SADD", userOutHooksKey(record.Login), record.Id)
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+// Reads hook by id, nil if there is no such hook
+func readOutHook(conn redis.Conn, id string) (*outHookRecord, error) {
+	values, err := redis.Values(conn.Do("HGETALL", outHookKey(id)))
+	if err != nil {
+		return nil, err
+	}
+	record := &outHookRecord{}
+	if err := redis.ScanStruct(values, record); err != nil {
+		return nil, err
+	}
+	if record.Id == "" {
+		return nil, nil
+	}
+	return record, nil
+}
+
+// Reads hooks of the set: all hooks or hooks of the user
+func listOutHooksFromDB(ctx context.Context, setKey string) ([]*outHookRecord, error) {
+	defer mmw.ObserveDB("redis", "list_outhooks", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ids, err := redis.Strings(conn.Do("SMEMBERS", setKey))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*outHookRecord, 0, len(ids))
+	for _, id := range ids {
+		record, err := readOutHook(conn, id)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(a, b int) bool { return records[a].CreatedAt < records[b].CreatedAt })
+
+	return records, nil
+}
+
+// Deletes hook of the user, false if user has no such hook
+func deleteOutHookFromDB(ctx context.Context, login, id string) (bool, error) {
+	defer mmw.ObserveDB("redis", "delete_outhook", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	owned, err := redis.Bool(conn.Do("SISMEMBER", userOutHooksKey(login), id))
+	if err != nil || !owned {
+		return false, err
+	}
+	conn.Send("MULTI")
+	conn.Send("DEL", outHookKey(id))
+	conn.Send("SREM", outHooksKey, id)
+	conn.Send("SREM", userOutHooksKey(login), id)
+	_, err = conn.Do("EXEC")
+	return err == nil, err
+}
+
+// Reads dead letters of hooks of the user
+func listDeadLettersFromDB(ctx context.Context, login string) ([]*delivery, error) {
+	defer mmw.ObserveDB("redis", "list_dead_letters", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	values, err := redis.ByteSlices(conn.Do("LRANGE", events.DeadLettersKey(login), 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	letters := make([]*delivery, 0)
+	for _, v := range values {
+		d := &delivery{}
+		if err := json.Unmarshal(v, d); err != nil {
+			continue
+		}
+		letters = append(letters, d)
+	}
+	return letters, nil
+}
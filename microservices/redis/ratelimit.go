@@ -0,0 +1,73 @@
+// Implements RateLimiter service: fixed window counters, used by main to limit posting
+// "ratelimit:<key>" - hash with Count of hits, it expires with the window
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+type RPCRateLimiter struct{}
+
+func rateLimitKey(key string) string {
+	return "ratelimit:" + key
+}
+
+// grpc Allow implementation
+func (l RPCRateLimiter) Allow(ctx context.Context, i *grpcconnector.AllowRequest) (*grpcconnector.AllowResponse, error) {
+	log := logs.With(ctx, logger)
+	switch {
+	case i.Key == "":
+		return nil, errs.Invalid("Key", "Key is not supplied")
+	case i.Limit <= 0:
+		return nil, errs.Invalid("Limit", "Limit must be positive")
+	case i.Window <= 0:
+		return nil, errs.Invalid("Window", "Window must be positive")
+	}
+	count, ttl, err := hitInDB(ctx, rateLimitKey(i.Key), i.Window)
+	if err != nil {
+		mmw.LogError(log, "Error during rate limit counting", err)
+		return nil, errs.Database(err)
+	}
+	if count > i.Limit {
+		log.Infof("Rate limit of \"%s\" is exceeded", i.Key)
+		return &grpcconnector.AllowResponse{RetryAfter: ttl}, nil
+	}
+
+	return &grpcconnector.AllowResponse{Allowed: true}, nil
+}
+
+// KEYS: counter; ARGV: window. Counts hit and starts the window with the first hit in one step,
+// so counter never stays without expiration, returns number of hits and seconds left
+var hitScript = redis.NewScript(1, `
+local count = redis.call("HINCRBY", KEYS[1], "Count", 1)
+local ttl = redis.call("TTL", KEYS[1])
+if ttl < 0 then
+	redis.call("EXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// Counts hit of the key, returns number of hits and seconds left in the window
+func hitInDB(ctx context.Context, key string, window int64) (int64, int64, error) {
+	defer mmw.ObserveDB("redis", "rate_limit", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	values, err := redis.Int64s(hitScript.Do(conn, key, window))
+	if err != nil {
+		return 0, 0, err
+	}
+	return values[0], values[1], nil
+}
@@ -1,46 +1,50 @@
 // implementation of grpc read, reads data from redis
 // We establish new connection everytime.
 
-package main
+package redisservice
 
 import (
+	mmw "chat_room_go/microservices"
 	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
 	"context"
+	"time"
 
 	"github.com/gomodule/redigo/redis"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 type RPCReader struct{}
 
 // grpc Read implementation
 func (w RPCReader) Read(ctx context.Context, i *grpcconnector.ReadRequest) (*grpcconnector.ReadResponse, error) {
-	logger.Info(ctx, i)
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return &grpcconnector.ReadResponse{Status: 404, Desription: "Metadata was not found"}, status.Errorf(codes.NotFound, "Metadata was not found")
-	}
-	expirationTimes, ok := md["expirationtime"]
-	if !ok || len(expirationTimes) != 1 {
-		return &grpcconnector.ReadResponse{Status: 404, Desription: "expirationtime is not supplied"}, status.Errorf(codes.NotFound, "expirationtime is not supplied")
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	expirationTime, err := mmw.RequiredMetadata(ctx, "expirationtime")
+	if err != nil {
+		return nil, err
 	}
-	expirationTime := expirationTimes[0]
 
-	toReturn, err := readFromDB(expirationTime, i.Login)
+	toReturn, err := readFromDB(ctx, expirationTime, i.Login)
 	if err != nil {
-		logger.Errorf("Error during table reading \"%s\"", err)
-		return &grpcconnector.ReadResponse{Status: 500, Desription: "Error during table reading"}, status.Errorf(codes.NotFound, "Error during table reading: %s", err)
+		mmw.LogError(log, "Error during table reading", err)
+		return nil, errs.Database(err)
+	}
+	if toReturn == nil {
+		return nil, errs.New(errs.NotFound, errs.ReasonUserNotFound, "User not found")
 	}
 
-	logger.Info(toReturn)
-	return &grpcconnector.ReadResponse{Result: toReturn, Status: 0, Desription: "Ok"}, nil
+	log.Info(toReturn)
+	return &grpcconnector.ReadResponse{Result: toReturn}, nil
 }
 
 // Reads user info from db, if user not found - empty struct
-func readFromDB(expirationTime, login string) (*grpcconnector.UserInfo, error) {
-	conn := pool.Get()
+func readFromDB(ctx context.Context, expirationTime, login string) (*grpcconnector.UserInfo, error) {
+	defer mmw.ObserveDB("redis", "hgetall", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer conn.Close()
 
 	values, err := redis.Values(conn.Do("HGETALL", login))
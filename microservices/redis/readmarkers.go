@@ -0,0 +1,139 @@
+// Implements ReadMarkers service: last read message of every user in every room, main moves them when client acknowledges messages
+// "lastread:<login>" - hash of message ids by room, "readers:<room>" - hash of message ids by login, the same markers both ways
+// Ids of messages grow with time, so marker only goes forward
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+type RPCReadMarkers struct{}
+
+func lastReadKey(login string) string {
+	return "lastread:" + login
+}
+
+func readersKey(room string) string {
+	return "readers:" + room
+}
+
+// grpc MarkRead implementation, returns marker as it is after the call
+func (m RPCReadMarkers) MarkRead(ctx context.Context, i *grpcconnector.MarkReadRequest) (*grpcconnector.ReadMarker, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	marker := i.GetMarker()
+	switch {
+	case marker.GetLogin() == "":
+		return nil, errs.Invalid("marker.Login", "Login is not supplied")
+	case marker.GetRoom() == "":
+		return nil, errs.Invalid("marker.Room", "Room is not supplied")
+	case marker.GetMessageId() == "":
+		return nil, errs.Invalid("marker.MessageId", "MessageId is not supplied")
+	}
+	id, err := markReadInDB(ctx, marker.Login, marker.Room, marker.MessageId)
+	if err != nil {
+		mmw.LogError(log, "Error during read marker update", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.ReadMarker{Login: marker.Login, Room: marker.Room, MessageId: id}, nil
+}
+
+// grpc GetReadMarkers implementation, rooms, the user has never read, have no marker
+func (m RPCReadMarkers) GetReadMarkers(ctx context.Context, i *grpcconnector.GetReadMarkersRequest) (*grpcconnector.GetReadMarkersResponse, error) {
+	log := logs.With(ctx, logger)
+	if i.Login == "" {
+		return nil, errs.Invalid("Login", "Login is not supplied")
+	}
+	ids, err := readMarkersFromDB(ctx, lastReadKey(i.Login))
+	if err != nil {
+		mmw.LogError(log, "Error during read markers reading", err)
+		return nil, errs.Database(err)
+	}
+
+	toReturn := make([]*grpcconnector.ReadMarker, 0, len(ids))
+	for room, id := range ids {
+		toReturn = append(toReturn, &grpcconnector.ReadMarker{Login: i.Login, Room: room, MessageId: id})
+	}
+	sort.Slice(toReturn, func(a, b int) bool { return toReturn[a].Room < toReturn[b].Room })
+	return &grpcconnector.GetReadMarkersResponse{Results: toReturn}, nil
+}
+
+// grpc ListReaders implementation
+func (m RPCReadMarkers) ListReaders(ctx context.Context, i *grpcconnector.ListReadersRequest) (*grpcconnector.ListReadersResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	switch {
+	case i.Room == "":
+		return nil, errs.Invalid("Room", "Room is not supplied")
+	case i.MessageId == "":
+		return nil, errs.Invalid("MessageId", "MessageId is not supplied")
+	}
+	ids, err := readMarkersFromDB(ctx, readersKey(i.Room))
+	if err != nil {
+		mmw.LogError(log, "Error during readers reading", err)
+		return nil, errs.Database(err)
+	}
+
+	var toReturn []*grpcconnector.ReadMarker
+	for login, id := range ids {
+		if !olderID(id, i.MessageId) {
+			toReturn = append(toReturn, &grpcconnector.ReadMarker{Login: login, Room: i.Room, MessageId: id})
+		}
+	}
+	sort.Slice(toReturn, func(a, b int) bool { return toReturn[a].Login < toReturn[b].Login })
+	return &grpcconnector.ListReadersResponse{Results: toReturn}, nil
+}
+
+// Tells if message a was written before message b
+func olderID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// KEYS: markers of the user, readers of the room; ARGV: room, login, message id
+// Compares and sets in one step, so marker never goes back, when clients of the user race; ids are compared as olderID does
+var markReadScript = redis.NewScript(2, `
+local current = redis.call("HGET", KEYS[1], ARGV[1])
+if current and (#current > #ARGV[3] or (#current == #ARGV[3] and current >= ARGV[3])) then
+	return current
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[3])
+redis.call("HSET", KEYS[2], ARGV[2], ARGV[3])
+return ARGV[3]
+`)
+
+// Moves marker of the user in the room to the message, unless it is further already, returns the marker
+func markReadInDB(ctx context.Context, login, room, id string) (string, error) {
+	defer mmw.ObserveDB("redis", "mark_read", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	return redis.String(markReadScript.Do(conn, lastReadKey(login), readersKey(room), room, login, id))
+}
+
+// Reads hash of markers: by room for user or by login for room
+func readMarkersFromDB(ctx context.Context, key string) (map[string]string, error) {
+	defer mmw.ObserveDB("redis", "read_markers", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return redis.StringMap(conn.Do("HGETALL", key))
+}
@@ -0,0 +1,245 @@
+// Implements Moderation service: bans, mutes and timeouts of users
+// "restriction:<login>" - hash with the current restriction, redis expires it with the restriction
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Kinds of restrictions
+const (
+	// User can not log in
+	RestrictionBan = "ban"
+	// User can read, but can not post
+	RestrictionMute = "mute"
+	// Mute, that expires
+	RestrictionTimeout = "timeout"
+)
+
+type RPCModeration struct{}
+
+// Restriction as stored in redis
+type restrictionRecord struct {
+	Login     string
+	Kind      string
+	Reason    string
+	By        string
+	CreatedAt string
+	ExpiresAt string
+}
+
+func (r *restrictionRecord) info() *grpcconnector.Restriction {
+	return &grpcconnector.Restriction{
+		Login:     r.Login,
+		Kind:      r.Kind,
+		Reason:    r.Reason,
+		By:        r.By,
+		CreatedAt: r.CreatedAt,
+		ExpiresAt: r.ExpiresAt,
+	}
+}
+
+func restrictionKey(login string) string {
+	return "restriction:" + login
+}
+
+// grpc SetRestriction implementation, ban also ends all sessions of the user
+func (m RPCModeration) SetRestriction(ctx context.Context, i *grpcconnector.SetRestrictionRequest) (*grpcconnector.Restriction, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	r := i.GetRestriction()
+	switch {
+	case r.GetLogin() == "":
+		return nil, errs.Invalid("restriction.Login", "Login is required")
+	case r.GetKind() != RestrictionBan && r.GetKind() != RestrictionMute && r.GetKind() != RestrictionTimeout:
+		return nil, errs.Invalid("restriction.Kind", "Kind must be ban, mute or timeout")
+	case i.Seconds < 0:
+		return nil, errs.Invalid("Seconds", "Seconds can not be negative")
+	case r.GetKind() == RestrictionTimeout && i.Seconds == 0:
+		return nil, errs.Invalid("Seconds", "Timeout requires duration")
+	}
+
+	now := time.Now()
+	record := &restrictionRecord{
+		Login:     r.Login,
+		Kind:      r.Kind,
+		Reason:    r.Reason,
+		By:        r.By,
+		CreatedAt: now.Format("2006-01-02 15:04:05"),
+	}
+	if i.Seconds > 0 {
+		record.ExpiresAt = now.Add(time.Duration(i.Seconds) * time.Second).Format("2006-01-02 15:04:05")
+	}
+	found, err := writeRestrictionToDB(ctx, record, i.Seconds)
+	if err != nil {
+		mmw.LogError(log, "Error during restriction insertion", err)
+		return nil, errs.Database(err)
+	}
+	if !found {
+		return nil, errs.New(errs.NotFound, errs.ReasonUserNotFound, "User not found")
+	}
+	if record.Kind == RestrictionBan {
+		if _, err := killSessionsInDB(ctx, record.Login); err != nil {
+			mmw.LogError(log, "Error during sessions deletion", err)
+			return nil, errs.Database(err)
+		}
+	}
+
+	return record.info(), nil
+}
+
+// grpc GetRestriction implementation
+func (m RPCModeration) GetRestriction(ctx context.Context, i *grpcconnector.GetRestrictionRequest) (*grpcconnector.Restriction, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	record, err := readRestrictionFromDB(ctx, i.Login)
+	if err != nil {
+		mmw.LogError(log, "Error during restriction reading", err)
+		return nil, errs.Database(err)
+	}
+	if record == nil {
+		return &grpcconnector.Restriction{Login: i.Login}, nil
+	}
+
+	return record.info(), nil
+}
+
+// grpc ClearRestriction implementation
+func (m RPCModeration) ClearRestriction(ctx context.Context, i *grpcconnector.ClearRestrictionRequest) (*grpcconnector.ClearRestrictionResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	if i.Login == "" {
+		return nil, errs.Invalid("Login", "Login is not supplied")
+	}
+	if err := clearRestrictionInDB(ctx, i.Login); err != nil {
+		mmw.LogError(log, "Error during restriction deletion", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.ClearRestrictionResponse{}, nil
+}
+
+// grpc ListRestrictions implementation, restrictions are sorted by login
+func (m RPCModeration) ListRestrictions(ctx context.Context, i *grpcconnector.ListRestrictionsRequest) (*grpcconnector.ListRestrictionsResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	records, err := listRestrictionsFromDB(ctx)
+	if err != nil {
+		mmw.LogError(log, "Error during restrictions listing", err)
+		return nil, errs.Database(err)
+	}
+	results := make([]*grpcconnector.Restriction, 0, len(records))
+	for _, r := range records {
+		results = append(results, r.info())
+	}
+
+	return &grpcconnector.ListRestrictionsResponse{Results: results}, nil
+}
+
+// Replaces restriction of the user, false if there is no such user
+func writeRestrictionToDB(ctx context.Context, record *restrictionRecord, seconds int64) (bool, error) {
+	defer mmw.ObserveDB("redis", "set_restriction", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	exists, err := redis.Bool(conn.Do("HEXISTS", record.Login, "Login"))
+	if err != nil || !exists {
+		return false, err
+	}
+	key := restrictionKey(record.Login)
+	conn.Send("MULTI")
+	conn.Send("DEL", key)
+	conn.Send("HSET", redis.Args{}.Add(key).AddFlat(record)...)
+	if seconds > 0 {
+		conn.Send("EXPIRE", key, seconds)
+	}
+	_, err = conn.Do("EXEC")
+	return err == nil, err
+}
+
+// Reads restriction of the user, nil if there is none
+func readRestrictionFromDB(ctx context.Context, login string) (*restrictionRecord, error) {
+	defer mmw.ObserveDB("redis", "get_restriction", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return readRestriction(conn, restrictionKey(login))
+}
+
+func readRestriction(conn redis.Conn, key string) (*restrictionRecord, error) {
+	values, err := redis.Values(conn.Do("HGETALL", key))
+	if err != nil {
+		return nil, err
+	}
+	record := &restrictionRecord{}
+	if err := redis.ScanStruct(values, record); err != nil {
+		return nil, err
+	}
+	if record.Kind == "" {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func clearRestrictionInDB(ctx context.Context, login string) error {
+	defer mmw.ObserveDB("redis", "clear_restriction", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("DEL", restrictionKey(login))
+	return err
+}
+
+// Reads all current restrictions
+func listRestrictionsFromDB(ctx context.Context) ([]*restrictionRecord, error) {
+	defer mmw.ObserveDB("redis", "list_restrictions", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var records []*restrictionRecord
+	err = scanKeys(conn, restrictionKey("*"), "hash", func(key string) error {
+		record, err := readRestriction(conn, key)
+		// Restriction expired during scan
+		if err != nil || record == nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(records, func(a, b int) bool { return records[a].Login < records[b].Login })
+
+	return records, nil
+}
+
+// Tells if the user is banned, tokens of banned users do not work
+func isBanned(conn redis.Conn, login string) (bool, error) {
+	kind, err := redis.String(conn.Do("HGET", restrictionKey(login), "Kind"))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	return kind == RestrictionBan, err
+}
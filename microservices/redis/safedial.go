@@ -0,0 +1,90 @@
+// Outgoing hooks must not reach the internal network: host of the hook is checked when the hook is created,
+// and every connection of delivery is checked again when it is dialed, so DNS rebinding does not get around it
+
+package redisservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var errPrivateAddress = errors.New("address is not public")
+
+// Loopback, private, shared, link-local, multicast, reserved and unspecified networks
+var privateNets = parseNets(
+	"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16", "172.16.0.0/12",
+	"192.0.0.0/24", "192.168.0.0/16", "198.18.0.0/15", "224.0.0.0/4", "240.0.0.0/4",
+	"::/128", "::1/128", "fc00::/7", "fe80::/10", "ff00::/8",
+)
+
+func parseNets(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for n, cidr := range cidrs {
+		_, nets[n], _ = net.ParseCIDR(cidr)
+	}
+	return nets
+}
+
+// Tells if ip is not reachable from the internet, ipv4 mapped to ipv6 is checked as ipv4
+func privateIP(ip net.IP) bool {
+	for _, n := range privateNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolves host of the hook, error if it has no address or any of its addresses is not public
+func checkHookHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if privateIP(ip) {
+			return errPrivateAddress
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("host %s has no address", host)
+	}
+	for _, a := range addrs {
+		if privateIP(a.IP) {
+			return errPrivateAddress
+		}
+	}
+	return nil
+}
+
+// Control of dialer, refuses connections to addresses, that are not public
+func publicOnly(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || privateIP(ip) {
+		return fmt.Errorf("%w: %s", errPrivateAddress, host)
+	}
+	return nil
+}
+
+// Http client of delivery: no proxy, public addresses only, also after redirects
+func hookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicOnly}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
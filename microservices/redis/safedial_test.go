@@ -0,0 +1,68 @@
+package redisservice
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestPrivateIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		private bool
+	}{
+		{"127.0.0.1", true},
+		{"127.8.8.8", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"172.31.255.255", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"::", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"172.32.0.1", false},
+		{"2606:4700::1111", false},
+	}
+	for _, tt := range tests {
+		if got := privateIP(net.ParseIP(tt.ip)); got != tt.private {
+			t.Errorf("privateIP(%s) = %v, want %v", tt.ip, got, tt.private)
+		}
+	}
+}
+
+func TestCheckHookHostLiterals(t *testing.T) {
+	for _, host := range []string{"127.0.0.1", "::1", "169.254.169.254", "10.0.0.1"} {
+		if err := checkHookHost(context.Background(), host); !errors.Is(err, errPrivateAddress) {
+			t.Errorf("checkHookHost(%s) = %v, want errPrivateAddress", host, err)
+		}
+	}
+	if err := checkHookHost(context.Background(), "93.184.216.34"); err != nil {
+		t.Errorf("checkHookHost(public) = %v", err)
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	tests := []struct {
+		address string
+		ok      bool
+	}{
+		{"127.0.0.1:80", false},
+		{"[::1]:443", false},
+		{"192.168.0.10:8080", false},
+		{"93.184.216.34:443", true},
+		{"[2606:4700::1111]:443", true},
+	}
+	for _, tt := range tests {
+		err := publicOnly("tcp", tt.address, nil)
+		if (err == nil) != tt.ok {
+			t.Errorf("publicOnly(%s) = %v, want ok %v", tt.address, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Redis microservice as a library, grpc services are registered by the caller
+// Used by the standalone server (cmd) and by all-in-one mode of main
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	"chat_room_go/microservices/events"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/logs"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+var logger *zap.SugaredLogger
+
+// Gauges are registered on the first Register only, registry panics on duplicates
+var registerGauges sync.Once
+
+func init() {
+	logger = logs.InitDirLogger(config.Config.RedisAdapter.PathToLogs)
+}
+
+// Registers all grpc services of the microservice, including health service, that pings redis until ctx is done, starts delivery of outgoing webhooks
+func Register(ctx context.Context, server *grpc.Server) {
+	mmw.RegisterHealth(ctx, server, Ping)
+	registerGauges.Do(func() {
+		prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "chat_active_sessions",
+			Help: "Number of not expired sessions in redis",
+		}, countSessions))
+	})
+	grpcconnector.RegisterWriterServer(server, RPCWriter{})
+	grpcconnector.RegisterReaderServer(server, RPCReader{})
+	grpcconnector.RegisterGetterSessionServer(server, RPCReader{})
+	grpcconnector.RegisterWriterSessionServer(server, RPCWriter{})
+	grpcconnector.RegisterTokensServer(server, RPCTokens{})
+	grpcconnector.RegisterIncomingHooksServer(server, RPCIncomingHooks{})
+	grpcconnector.RegisterOutgoingHooksServer(server, RPCOutgoingHooks{})
+	grpcconnector.RegisterAdminServer(server, RPCAdmin{})
+	grpcconnector.RegisterModerationServer(server, RPCModeration{})
+	grpcconnector.RegisterRateLimiterServer(server, RPCRateLimiter{})
+	grpcconnector.RegisterMentionsServer(server, RPCMentions{})
+	grpcconnector.RegisterReadMarkersServer(server, RPCReadMarkers{})
+	startDelivery()
+}
+
+// Checks that redis is reachable
+func Ping(ctx context.Context) error {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("PING")
+	return err
+}
+
+// Counts not expired sessions in sorted set of all sessions, expired ones are removed by session code, not by scrapes
+func countSessions() float64 {
+	conn := pool.Get()
+	defer conn.Close()
+
+	n, err := redis.Int64(conn.Do("ZCOUNT", sessionsKey, "("+strconv.FormatInt(time.Now().Unix(), 10), "+inf"))
+	if err != nil {
+		logger.Errorf("Error during sessions counting \"%s\"", err)
+		return 0
+	}
+	return float64(n)
+}
+
+// Stops delivery of outgoing webhooks, closes redis connection pools, flushes logger of the microservice
+func Close() error {
+	stopDelivery()
+	events.Close()
+	err := pool.Close()
+	logger.Sync()
+
+	return err
+}
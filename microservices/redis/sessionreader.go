@@ -1,56 +1,67 @@
 // implementation of grpc read, reads data from redis
 // We establish new connection everytime.
 
-package main
+package redisservice
 
 import (
+	mmw "chat_room_go/microservices"
 	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/gomodule/redigo/redis"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 // grpc Read implementation
 func (w RPCReader) GetSession(ctx context.Context, i *grpcconnector.GetSessionRequest) (*grpcconnector.GetSessionResponse, error) {
-	logger.Info(ctx, i)
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return &grpcconnector.GetSessionResponse{Status: 404, Desription: "Metadata was not found"}, status.Errorf(codes.NotFound, "Metadata was not found")
-	}
-	expirationTimes, ok := md["expirationtime"]
-	if !ok || len(expirationTimes) != 1 {
-		return &grpcconnector.GetSessionResponse{Status: 404, Desription: "expirationtime is not supplied"}, status.Errorf(codes.NotFound, "expirationtime is not supplied")
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	expirationTime, err := mmw.RequiredMetadata(ctx, "expirationtime")
+	if err != nil {
+		return nil, err
 	}
-	expirationTime := expirationTimes[0]
-	toReturn, err := readSessionFromDB(expirationTime, i.SessionId)
+	toReturn, err := readSessionFromDB(ctx, expirationTime, i.SessionId)
 	if err != nil {
-		logger.Errorf("Error during table reading \"%s\"", err)
-		return &grpcconnector.GetSessionResponse{Status: 500, Desription: "Error during table reading"}, status.Errorf(codes.NotFound, "Error during table reading: %s", err)
+		mmw.LogError(log, "Error during table reading", err)
+		return nil, errs.Database(err)
+	}
+	if toReturn == "" {
+		return nil, errs.New(errs.NotFound, errs.ReasonSessionNotFound, "Session not found")
 	}
 
-	logger.Info(toReturn)
-	return &grpcconnector.GetSessionResponse{UserName: toReturn, Status: 0, Desription: "Ok"}, nil
+	log.Info(toReturn)
+	return &grpcconnector.GetSessionResponse{UserName: toReturn}, nil
 }
 
-// Reads user info from db, if user not found - empty struct, if found - refresh expiration time
-func readSessionFromDB(expirationTime, sessionId string) (string, error) {
+// Reads session from db, if session not found - empty string, if found - refresh expiration time
+func readSessionFromDB(ctx context.Context, expirationTime, sessionId string) (string, error) {
+	defer mmw.ObserveDB("redis", "get_session", time.Now())
 	expTime, err := strconv.Atoi(expirationTime)
+	if err != nil {
+		return "", errs.Invalid("expirationtime", "expirationtime is not a number")
+	}
+	conn, err := pool.GetContext(ctx)
 	if err != nil {
 		return "", err
 	}
-	conn := pool.Get()
 	defer conn.Close()
 
-	userName, err := redis.String(conn.Do("GET", sessionId))
-	if err != nil {
+	userName, err := redis.String(conn.Do("GET", sessionKey(sessionId)))
+	if err == redis.ErrNil {
+		return "", nil
+	} else if err != nil {
 		return "", err
 	}
-	_, err = conn.Do("EXPIRE", sessionId, expTime)
-	if err != nil {
+	expires := time.Now().Unix() + int64(expTime)
+	conn.Send("MULTI")
+	conn.Send("EXPIRE", sessionKey(sessionId), expTime)
+	conn.Send("ZADD", userSessionsKey(userName), "XX", expires, sessionId)
+	conn.Send("EXPIRE", userSessionsKey(userName), expTime)
+	conn.Send("ZADD", sessionsKey, "XX", expires, sessionId)
+	if _, err = conn.Do("EXEC"); err != nil {
 		return "", err
 	}
 
@@ -1,18 +1,33 @@
 // Implements Write function
 // We establish new connection everytime.
 
-package main
+package redisservice
 
 import (
+	mmw "chat_room_go/microservices"
 	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
 	"context"
 	"strconv"
+	"time"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
+	"github.com/gomodule/redigo/redis"
 )
 
+// "session:<id>" - login of the session, expires with it
+// "usersessions:<login>" and "sessions" - sorted sets of session ids by expiration time, of the user and of all users,
+// so sessions are found without SCAN, expired ids are removed from them when they are listed
+const sessionsKey = "sessions"
+
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+func userSessionsKey(login string) string {
+	return "usersessions:" + login
+}
+
 const (
 //cacheSize int    = 20
 )
@@ -31,39 +46,81 @@ const (
 
 // grpc Write implementation
 func (w RPCWriter) AddSession(ctx context.Context, i *grpcconnector.AddSessionRequest) (*grpcconnector.AddSessionResponse, error) {
-	logger.Info(ctx, i)
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return &grpcconnector.AddSessionResponse{Status: 404, Desription: "Metadata was not found"}, status.Errorf(codes.NotFound, "Metadata was not found")
-	}
-	expirationTimes, ok := md["expirationtime"]
-	if !ok || len(expirationTimes) != 1 {
-		return &grpcconnector.AddSessionResponse{Status: 404, Desription: "expirationtime is not supplied"}, status.Errorf(codes.NotFound, "expirationtime is not supplied")
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	expirationTime, err := mmw.RequiredMetadata(ctx, "expirationtime")
+	if err != nil {
+		return nil, err
 	}
-	expirationTime := expirationTimes[0]
 
-	err := writeSessionToDB(expirationTime, i)
+	err = writeSessionToDB(ctx, expirationTime, i)
 	if err != nil {
-		logger.Errorf("Error during table insertion \"%s\"", err)
-		return &grpcconnector.AddSessionResponse{Status: 500, Desription: "Error during table insertion"}, status.Errorf(codes.NotFound, "Error during table insertion: %s", err)
+		mmw.LogError(log, "Error during table insertion", err)
+		return nil, errs.Database(err)
 	}
 
-	logger.Info("Response ok")
-	return &grpcconnector.AddSessionResponse{Status: 0, Desription: "Ok"}, nil
+	log.Info("Response ok")
+	return &grpcconnector.AddSessionResponse{}, nil
 }
 
 // Writes message to redis
-func writeSessionToDB(expirationTime string, i *grpcconnector.AddSessionRequest) error {
+func writeSessionToDB(ctx context.Context, expirationTime string, i *grpcconnector.AddSessionRequest) error {
+	defer mmw.ObserveDB("redis", "set_session", time.Now())
 	expTime, err := strconv.Atoi(expirationTime)
+	if err != nil {
+		return errs.Invalid("expirationtime", "expirationtime is not a number")
+	}
+	conn, err := pool.GetContext(ctx)
 	if err != nil {
 		return err
 	}
-	conn := pool.Get()
 	defer conn.Close()
-	_, err = conn.Do("SET", i.SessionId, i.UserName, "EX", expTime)
+	expires := time.Now().Unix() + int64(expTime)
+	conn.Send("MULTI")
+	conn.Send("SET", sessionKey(i.SessionId), i.UserName, "EX", expTime)
+	conn.Send("ZADD", userSessionsKey(i.UserName), expires, i.SessionId)
+	conn.Send("EXPIRE", userSessionsKey(i.UserName), expTime)
+	conn.Send("ZADD", sessionsKey, expires, i.SessionId)
+	// Expired sessions leave the index on login, so it does not grow between listings
+	conn.Send("ZREMRANGEBYSCORE", sessionsKey, "-inf", time.Now().Unix())
+	_, err = conn.Do("EXEC")
+
+	return err
+}
+
+// grpc DeleteSession implementation, deleting missing session is not an error
+func (w RPCWriter) DeleteSession(ctx context.Context, i *grpcconnector.DeleteSessionRequest) (*grpcconnector.DeleteSessionResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	err := deleteSessionFromDB(ctx, i.SessionId)
+	if err != nil {
+		mmw.LogError(log, "Error during session deletion", err)
+		return nil, errs.Database(err)
+	}
+
+	log.Info("Response ok")
+	return &grpcconnector.DeleteSessionResponse{}, nil
+}
+
+// Deletes session from redis
+func deleteSessionFromDB(ctx context.Context, sessionId string) error {
+	defer mmw.ObserveDB("redis", "del_session", time.Now())
+	conn, err := pool.GetContext(ctx)
 	if err != nil {
 		return err
 	}
+	defer conn.Close()
+	owner, err := redis.String(conn.Do("GET", sessionKey(sessionId)))
+	if err == redis.ErrNil {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	conn.Send("MULTI")
+	conn.Send("DEL", sessionKey(sessionId))
+	conn.Send("ZREM", userSessionsKey(owner), sessionId)
+	conn.Send("ZREM", sessionsKey, sessionId)
+	_, err = conn.Do("EXEC")
 
-	return nil
+	return err
 }
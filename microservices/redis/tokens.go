@@ -0,0 +1,265 @@
+// Personal api tokens: created by user, checked by main on every bearer request, revoked by owner
+// Secret is returned once, redis stores only its sha256 hash:
+// "apitoken:<hash>" - hash with token info, "apitokens:<login>" - hash of token id to token hash
+
+package redisservice
+
+import (
+	mmw "chat_room_go/microservices"
+	grpcconnector "chat_room_go/microservices/redis/pb"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Prefix of token secrets, lets main tell tokens from session ids
+const TokenPrefix = "crt_"
+
+type RPCTokens struct{}
+
+// Token as stored in redis, scopes are joined by space
+type tokenRecord struct {
+	Id        string
+	Login     string
+	Name      string
+	Scopes    string
+	CreatedAt string
+	LastUsed  string
+}
+
+func (t *tokenRecord) info() *grpcconnector.TokenInfo {
+	return &grpcconnector.TokenInfo{
+		Id:        t.Id,
+		Login:     t.Login,
+		Name:      t.Name,
+		Scopes:    strings.Fields(t.Scopes),
+		CreatedAt: t.CreatedAt,
+		LastUsed:  t.LastUsed,
+	}
+}
+
+func tokenKey(hash string) string {
+	return "apitoken:" + hash
+}
+
+func userTokensKey(login string) string {
+	return "apitokens:" + login
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// grpc CreateToken implementation
+func (t RPCTokens) CreateToken(ctx context.Context, i *grpcconnector.CreateTokenRequest) (*grpcconnector.CreateTokenResponse, error) {
+	log := logs.With(ctx, logger)
+	tok := i.GetToken()
+	switch {
+	case tok.GetLogin() == "":
+		return nil, errs.Invalid("token.Login", "Login is required")
+	case tok.GetName() == "":
+		return nil, errs.Invalid("token.Name", "Name is required")
+	case len(tok.GetScopes()) == 0:
+		return nil, errs.Invalid("token.Scopes", "At least one scope is required")
+	}
+	log.Infof("Creating token \"%s\" of \"%s\"", tok.Name, tok.Login)
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal, errs.ReasonInternal, "Error during token generation", err)
+	}
+	secret, err := randomHex(20)
+	if err != nil {
+		return nil, errs.Wrap(errs.Internal, errs.ReasonInternal, "Error during token generation", err)
+	}
+	secret = TokenPrefix + secret
+	record := &tokenRecord{
+		Id:        id,
+		Login:     tok.Login,
+		Name:      tok.Name,
+		Scopes:    strings.Join(tok.Scopes, " "),
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	err = writeTokenToDB(ctx, hashSecret(secret), record)
+	if err != nil {
+		mmw.LogError(log, "Error during token insertion", err)
+		return nil, errs.Database(err)
+	}
+
+	return &grpcconnector.CreateTokenResponse{Token: record.info(), Secret: secret}, nil
+}
+
+// grpc CheckToken implementation, also updates last usage of the token
+func (t RPCTokens) CheckToken(ctx context.Context, i *grpcconnector.CheckTokenRequest) (*grpcconnector.TokenInfo, error) {
+	log := logs.With(ctx, logger)
+	record, err := checkTokenInDB(ctx, hashSecret(i.Secret))
+	if err != nil {
+		mmw.LogError(log, "Error during token reading", err)
+		return nil, errs.Database(err)
+	}
+	if record == nil {
+		return nil, errs.New(errs.NotFound, errs.ReasonTokenNotFound, "Token not found")
+	}
+
+	return record.info(), nil
+}
+
+// grpc ListTokens implementation, tokens are sorted by creation time
+func (t RPCTokens) ListTokens(ctx context.Context, i *grpcconnector.ListTokensRequest) (*grpcconnector.ListTokensResponse, error) {
+	log := logs.With(ctx, logger)
+	records, err := listTokensFromDB(ctx, i.Login)
+	if err != nil {
+		mmw.LogError(log, "Error during tokens reading", err)
+		return nil, errs.Database(err)
+	}
+	results := make([]*grpcconnector.TokenInfo, 0, len(records))
+	for _, r := range records {
+		results = append(results, r.info())
+	}
+
+	return &grpcconnector.ListTokensResponse{Results: results}, nil
+}
+
+// grpc RevokeToken implementation
+func (t RPCTokens) RevokeToken(ctx context.Context, i *grpcconnector.RevokeTokenRequest) (*grpcconnector.RevokeTokenResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Infof("Revoking token \"%s\" of \"%s\"", i.Id, i.Login)
+	found, err := revokeTokenInDB(ctx, i.Login, i.Id)
+	if err != nil {
+		mmw.LogError(log, "Error during token revocation", err)
+		return nil, errs.Database(err)
+	}
+	if !found {
+		return nil, errs.New(errs.NotFound, errs.ReasonTokenNotFound, "Token not found")
+	}
+
+	return &grpcconnector.RevokeTokenResponse{}, nil
+}
+
+// Writes token and adds it to tokens of the user
+func writeTokenToDB(ctx context.Context, hash string, record *tokenRecord) error {
+	defer mmw.ObserveDB("redis", "create_token", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("HSET", redis.Args{}.Add(tokenKey(hash)).AddFlat(record)...)
+	conn.Send("HSET", userTokensKey(record.Login), record.Id, hash)
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+// Reads token by hash of secret, nil if there is no such token
+func checkTokenInDB(ctx context.Context, hash string) (*tokenRecord, error) {
+	defer mmw.ObserveDB("redis", "check_token", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	record, err := readToken(conn, hash)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	// Tokens of disabled and banned users do not work, as their passwords
+	disabled, err := redis.Bool(conn.Do("HEXISTS", record.Login, "Disabled"))
+	if err != nil || disabled {
+		return nil, err
+	}
+	if banned, err := isBanned(conn, record.Login); err != nil || banned {
+		return nil, err
+	}
+	record.LastUsed = time.Now().Format("2006-01-02 15:04:05")
+	_, err = conn.Do("HSET", tokenKey(hash), "LastUsed", record.LastUsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func readToken(conn redis.Conn, hash string) (*tokenRecord, error) {
+	values, err := redis.Values(conn.Do("HGETALL", tokenKey(hash)))
+	if err != nil {
+		return nil, err
+	}
+	record := &tokenRecord{}
+	if err := redis.ScanStruct(values, record); err != nil {
+		return nil, err
+	}
+	if record.Id == "" {
+		return nil, nil
+	}
+	return record, nil
+}
+
+// Reads all tokens of the user
+func listTokensFromDB(ctx context.Context, login string) ([]*tokenRecord, error) {
+	defer mmw.ObserveDB("redis", "list_tokens", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	hashes, err := redis.StringMap(conn.Do("HGETALL", userTokensKey(login)))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*tokenRecord, 0, len(hashes))
+	for _, hash := range hashes {
+		record, err := readToken(conn, hash)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(a, b int) bool { return records[a].CreatedAt < records[b].CreatedAt })
+
+	return records, nil
+}
+
+// Deletes token of the user, false if user has no such token
+func revokeTokenInDB(ctx context.Context, login, id string) (bool, error) {
+	defer mmw.ObserveDB("redis", "revoke_token", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	hash, err := redis.String(conn.Do("HGET", userTokensKey(login), id))
+	if err == redis.ErrNil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	conn.Send("MULTI")
+	conn.Send("DEL", tokenKey(hash))
+	conn.Send("HDEL", userTokensKey(login), id)
+	_, err = conn.Do("EXEC")
+	return err == nil, err
+}
@@ -1,18 +1,19 @@
 // Implements Write function
 // We establish new connection everytime.
 
-package main
+package redisservice
 
 import (
+	mmw "chat_room_go/microservices"
+	"chat_room_go/microservices/events"
 	grpcconnector "chat_room_go/microservices/redis/pb"
 	config "chat_room_go/utils/conf"
+	"chat_room_go/utils/errs"
+	"chat_room_go/utils/logs"
 	"context"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 var dbURL string = config.Config.RedisAdapter.DbURL
@@ -53,35 +54,97 @@ type RPCWriter struct{}
 
 // grpc Write implementation
 func (w RPCWriter) Write(ctx context.Context, i *grpcconnector.WriteRequest) (*grpcconnector.WriteResponse, error) {
-	logger.Info(ctx, i)
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "Metadata was not found"}, status.Errorf(codes.NotFound, "Metadata was not found")
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	expirationTime, err := mmw.RequiredMetadata(ctx, "expirationtime")
+	if err != nil {
+		return nil, err
 	}
-	expirationTimes, ok := md["expirationtime"]
-	if !ok || len(expirationTimes) != 1 {
-		return &grpcconnector.WriteResponse{Status: 404, Desription: "expirationtime is not supplied"}, status.Errorf(codes.NotFound, "expirationtime is not supplied")
+
+	err = writeToDB(ctx, expirationTime, i)
+	if err != nil {
+		mmw.LogError(log, "Error during table insertion", err)
+		return nil, errs.Database(err)
 	}
-	expirationTime := expirationTimes[0]
 
-	err := writeToDB(expirationTime, i)
+	// Users are written once, at signup
+	events.Publish(ctx, events.UserSignup, events.User{Login: i.Login, FirstName: i.Fname, LastName: i.Lname})
+
+	log.Info("Response ok")
+	return &grpcconnector.WriteResponse{}, nil
+}
+
+// grpc SetNick implementation
+func (w RPCWriter) SetNick(ctx context.Context, i *grpcconnector.SetNickRequest) (*grpcconnector.SetNickResponse, error) {
+	log := logs.With(ctx, logger)
+	log.Info(i)
+	res, err := setNickInDB(ctx, i.Login, i.Nick)
 	if err != nil {
-		logger.Errorf("Error during table insertion \"%s\"", err)
-		return &grpcconnector.WriteResponse{Status: 500, Desription: "Error during table insertion"}, status.Errorf(codes.NotFound, "Error during table insertion: %s", err)
+		mmw.LogError(log, "Error during nick update", err)
+		return nil, errs.Database(err)
+	}
+	switch res {
+	case nickNoUser:
+		return nil, errs.New(errs.NotFound, errs.ReasonUserNotFound, "User not found")
+	case nickTaken:
+		return nil, errs.New(errs.AlreadyExists, errs.ReasonNickTaken, "Nick is a login or a nick of another user")
 	}
 
-	logger.Info("Response ok")
-	return &grpcconnector.WriteResponse{Status: 0, Desription: "Ok"}, nil
+	return &grpcconnector.SetNickResponse{}, nil
 }
 
 // Writes message to redis
-func writeToDB(expirationTime string, i *grpcconnector.WriteRequest) error {
-	conn := pool.Get()
+func writeToDB(ctx context.Context, expirationTime string, i *grpcconnector.WriteRequest) error {
+	defer mmw.ObserveDB("redis", "hset", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
 	defer conn.Close()
-	_, err := conn.Do("HSET", redis.Args{}.Add(i.Login).AddFlat(i)...)
+	_, err = conn.Do("HSET", redis.Args{}.Add(i.Login).AddFlat(i)...)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Results of setNickInDB
+const (
+	nickSet    = 1
+	nickNoUser = 0
+	nickTaken  = -1
+)
+
+// "nicks" - hash of logins by nick, so a nick belongs to one user
+const nicksKey = "nicks"
+
+// KEYS: user, nicks, user with login equal to the nick; ARGV: login, nick
+// Nick can not be a login or a nick of another user, old nick of the user is freed
+var setNickScript = redis.NewScript(3, `
+if redis.call("HEXISTS", KEYS[1], "Login") == 0 then return 0 end
+local old = redis.call("HGET", KEYS[1], "Nick")
+if ARGV[2] == "" then
+	redis.call("HDEL", KEYS[1], "Nick")
+else
+	if ARGV[2] ~= ARGV[1] and redis.call("HEXISTS", KEYS[3], "Login") == 1 then return -1 end
+	local owner = redis.call("HGET", KEYS[2], ARGV[2])
+	if owner and owner ~= ARGV[1] then return -1 end
+	redis.call("HSET", KEYS[2], ARGV[2], ARGV[1])
+	redis.call("HSET", KEYS[1], "Nick", ARGV[2])
+end
+if old and old ~= ARGV[2] then redis.call("HDEL", KEYS[2], old) end
+return 1
+`)
+
+// Sets display name of the user, empty nick removes it, returns nickSet, nickNoUser or nickTaken
+func setNickInDB(ctx context.Context, login, nick string) (int, error) {
+	defer mmw.ObserveDB("redis", "set_nick", time.Now())
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	return redis.Int(setNickScript.Do(conn, login, nicksKey, nick, login, nick))
+}
@@ -0,0 +1,38 @@
+// Graceful shutdown helpers for grpc servers
+
+package micromiddleware
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+)
+
+// Blocks until SIGINT or SIGTERM is received
+func WaitForSignal() os.Signal {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	return <-sigs
+}
+
+// Stops grpc server gracefully, if pending requests are not finished until ctx deadline - stops forcibly
+func GracefulStop(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Warnf("Graceful stop timed out, stopping forcibly")
+		server.Stop()
+	}
+	logger.Sync()
+}
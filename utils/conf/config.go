@@ -8,8 +8,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
+// Shutdown timeout, when config has none
+const defaultShutdownTimeout = 10 * time.Second
+
 var Config *Configuration
 
 func init() {
@@ -18,36 +22,87 @@ func init() {
 
 type Configuration struct {
 	ChatServeURL          string `json:"chatServeURL"`
+	MetricsURL            string `json:"metricsURL"`
 	SessionExpirationTime int    `json:"sessionExpirationTime"`
 	NumChatMessages       int    `json:"numChatMessages"`
+	ShutdownTimeout       int    `json:"shutdownTimeout"`
 	MongoAdapter          struct {
-		URL            string `json:"url"`
-		IntURL         string `json:"intURL"`
-		DbURL          string `json:"dbURL"`
-		DbName         string `json:"dbName"`
-		CollectionName string `json:"collectionName"`
-		TokenAuth      string `json:"tokenAuth"`
-		PathToLogs     string `json:"pathToLogs"`
+		URL                   string         `json:"url"`
+		IntURL                string         `json:"intURL"`
+		DbURL                 string         `json:"dbURL"`
+		DbName                string         `json:"dbName"`
+		CollectionName        string         `json:"collectionName"`
+		RoomsCollectionName   string         `json:"roomsCollectionName"`
+		ReportsCollectionName string         `json:"reportsCollectionName"`
+		DefaultRoom           string         `json:"defaultRoom"`
+		TokenAuth             string         `json:"tokenAuth"`
+		PathToLogs            string         `json:"pathToLogs"`
+		MetricsURL            string         `json:"metricsURL"`
+		RPCTimeouts           map[string]int `json:"rpcTimeouts"`
 	} `json:"mongoAdapter"`
 	RedisAdapter struct {
-		URL        string `json:"url"`
-		IntURL     string `json:"intURL"`
-		TokenAuth  string `json:"tokenAuth"`
-		DbURL      string `json:"dbURL"`
-		PathToLogs string `json:"pathToLogs"`
+		URL         string         `json:"url"`
+		IntURL      string         `json:"intURL"`
+		TokenAuth   string         `json:"tokenAuth"`
+		DbURL       string         `json:"dbURL"`
+		PathToLogs  string         `json:"pathToLogs"`
+		MetricsURL  string         `json:"metricsURL"`
+		RPCTimeouts map[string]int `json:"rpcTimeouts"`
 	} `json:"redisAdapter"`
 	ClickhouseAdapter struct {
-		URL        string `json:"url"`
-		IntURL     string `json:"intURL"`
-		DbName     string `json:"dbName"`
-		TableName  string `json:"tableName"`
-		TokenAuth  string `json:"tokenAuth"`
-		DbURL      string `json:"dbURL"`
-		PathToLogs string `json:"pathToLogs"`
+		URL            string         `json:"url"`
+		IntURL         string         `json:"intURL"`
+		DbName         string         `json:"dbName"`
+		TableName      string         `json:"tableName"`
+		AuditTableName string         `json:"auditTableName"`
+		TokenAuth      string         `json:"tokenAuth"`
+		DbURL          string         `json:"dbURL"`
+		PathToLogs     string         `json:"pathToLogs"`
+		MetricsURL     string         `json:"metricsURL"`
+		RPCTimeouts    map[string]int `json:"rpcTimeouts"`
 	} `json:"clickhouseAdapter"`
 	MicroserviceMiddleware struct {
 		PathToLogs string `json:"pathToLogs"`
 	} `json:"microserviceMiddleware"`
+	Resilience struct {
+		MaxRetries       int `json:"maxRetries"`
+		BackoffBase      int `json:"backoffBase"`
+		BackoffMax       int `json:"backoffMax"`
+		BreakerThreshold int `json:"breakerThreshold"`
+		BreakerCooldown  int `json:"breakerCooldown"`
+	} `json:"resilience"`
+	Tracing struct {
+		Exporter     string `json:"exporter"`
+		OTLPEndpoint string `json:"otlpEndpoint"`
+	} `json:"tracing"`
+	Webhooks struct {
+		IncomingRatePerMinute int `json:"incomingRatePerMinute"`
+		IncomingBurst         int `json:"incomingBurst"`
+		OutgoingMaxAttempts   int `json:"outgoingMaxAttempts"`
+		OutgoingBackoffBase   int `json:"outgoingBackoffBase"`
+		OutgoingBackoffMax    int `json:"outgoingBackoffMax"`
+		OutgoingTimeout       int `json:"outgoingTimeout"`
+		OutgoingWorkers       int `json:"outgoingWorkers"`
+		DeadLetterLimit       int `json:"deadLetterLimit"`
+	} `json:"webhooks"`
+	Filter struct {
+		Blocklist        []string `json:"blocklist"`
+		BlocklistMode    string   `json:"blocklistMode"`
+		AllowedLinkHosts []string `json:"allowedLinkHosts"`
+		MaxLength        int      `json:"maxLength"`
+		MaxLines         int      `json:"maxLines"`
+		FloodRepeats     int      `json:"floodRepeats"`
+		FloodWindow      int      `json:"floodWindow"`
+		PostsPerMinute   int      `json:"postsPerMinute"`
+	} `json:"filter"`
+}
+
+// Time for graceful shutdown, defaultShutdownTimeout if shutdownTimeout is not positive
+func (c *Configuration) ShutdownDuration() time.Duration {
+	if c.ShutdownTimeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return time.Duration(c.ShutdownTimeout) * time.Second
 }
 
 // Initialises configuration. File IO operations
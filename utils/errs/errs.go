@@ -0,0 +1,268 @@
+// Shared error model: domain errors with kind and reason
+// Microservices return them as grpc status with details, main maps grpc codes to http statuses
+
+package errs
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain of ErrorInfo details
+const Domain = "chat_room_go"
+
+// Kind of error, defines grpc code
+type Kind int
+
+const (
+	Internal Kind = iota
+	NotFound
+	AlreadyExists
+	InvalidArgument
+	Unauthenticated
+	PermissionDenied
+	Unavailable
+	DeadlineExceeded
+	Canceled
+	ResourceExhausted
+)
+
+var kindCodes = map[Kind]codes.Code{
+	Internal:          codes.Internal,
+	NotFound:          codes.NotFound,
+	AlreadyExists:     codes.AlreadyExists,
+	InvalidArgument:   codes.InvalidArgument,
+	Unauthenticated:   codes.Unauthenticated,
+	PermissionDenied:  codes.PermissionDenied,
+	Unavailable:       codes.Unavailable,
+	DeadlineExceeded:  codes.DeadlineExceeded,
+	Canceled:          codes.Canceled,
+	ResourceExhausted: codes.ResourceExhausted,
+}
+
+// Returns grpc code of the kind
+func (k Kind) Code() codes.Code {
+	if code, ok := kindCodes[k]; ok {
+		return code
+	}
+	return codes.Internal
+}
+
+// Machine readable reasons, go to ErrorInfo details
+const (
+	ReasonMetadataMissing     = "METADATA_MISSING"
+	ReasonInvalidRequest      = "INVALID_REQUEST"
+	ReasonUnauthenticated     = "UNAUTHENTICATED"
+	ReasonUserNotFound        = "USER_NOT_FOUND"
+	ReasonSessionNotFound     = "SESSION_NOT_FOUND"
+	ReasonRoomNotFound        = "ROOM_NOT_FOUND"
+	ReasonRoomExists          = "ROOM_EXISTS"
+	ReasonUserExists          = "USER_EXISTS"
+	ReasonUserDisabled        = "USER_DISABLED"
+	ReasonNickTaken           = "NICK_TAKEN"
+	ReasonTokenNotFound       = "TOKEN_NOT_FOUND"
+	ReasonScopeMissing        = "SCOPE_MISSING"
+	ReasonHookNotFound        = "HOOK_NOT_FOUND"
+	ReasonBadSignature        = "BAD_SIGNATURE"
+	ReasonBadCSRF             = "BAD_CSRF"
+	ReasonRateLimited         = "RATE_LIMITED"
+	ReasonMessageNotFound     = "MESSAGE_NOT_FOUND"
+	ReasonNotAuthor           = "NOT_AUTHOR"
+	ReasonNotAdmin            = "NOT_ADMIN"
+	ReasonRestrictionNotFound = "RESTRICTION_NOT_FOUND"
+	ReasonBanned              = "BANNED"
+	ReasonMuted               = "MUTED"
+	ReasonMessageRejected     = "MESSAGE_REJECTED"
+	ReasonFlood               = "FLOOD"
+	ReasonReportNotFound      = "REPORT_NOT_FOUND"
+	ReasonReportExists        = "REPORT_EXISTS"
+	ReasonReportResolved      = "REPORT_RESOLVED"
+	ReasonDatabaseUnavailable = "DATABASE_UNAVAILABLE"
+	ReasonDatabaseError       = "DATABASE_ERROR"
+	ReasonRequestCanceled     = "REQUEST_CANCELED"
+	ReasonInternal            = "INTERNAL"
+)
+
+// Domain error
+type Error struct {
+	Kind    Kind
+	Reason  string
+	Message string
+	// Wrong field of request or metadata key, for InvalidArgument
+	Field string
+	Err   error
+}
+
+// Creates error without cause
+func New(kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+// Creates error with cause
+func Wrap(kind Kind, reason, message string, err error) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message, Err: err}
+}
+
+// Creates InvalidArgument error for the field
+func Invalid(field, message string) *Error {
+	return &Error{Kind: InvalidArgument, Reason: ReasonInvalidRequest, Message: message, Field: field}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Converts error to grpc status with ErrorInfo and BadRequest details, used by grpc server
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Kind.Code(), e.Error())
+	details := []proto.Message{&errdetails.ErrorInfo{Reason: e.Reason, Domain: Domain}}
+	if e.Field != "" {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: e.Field, Description: e.Message}},
+		})
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// Classifies error of database call: context errors keep their meaning, network errors mean database is unavailable
+func Database(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return Wrap(DeadlineExceeded, ReasonDatabaseUnavailable, "Database did not answer in time", err)
+	case errors.Is(err, context.Canceled):
+		return Wrap(Canceled, ReasonRequestCanceled, "Request was canceled", err)
+	case errors.As(err, &netErr):
+		return Wrap(Unavailable, ReasonDatabaseUnavailable, "Database is unavailable", err)
+	}
+	return Wrap(Internal, ReasonDatabaseError, "Database error", err)
+}
+
+// Converts any error to grpc status error, errors without kind become Internal
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.GRPCStatus().Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return Wrap(Internal, ReasonInternal, "Internal error", err).GRPCStatus().Err()
+}
+
+// Returns reason of error from ErrorInfo details (or of domain error itself), empty if there is none
+func Reason(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+// Returns wrong field from BadRequest details (or of domain error itself), empty if there is none
+func Field(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Field
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok && len(br.FieldViolations) > 0 {
+			return br.FieldViolations[0].Field
+		}
+	}
+	return ""
+}
+
+// Http status of requests, that client canceled before the answer (as nginx logs them)
+const StatusClientClosedRequest = 499
+
+// Maps grpc code of the error to http status
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	switch status.Code(err) {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return StatusClientClosedRequest
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	}
+	return http.StatusInternalServerError
+}
+
+// Tells if the error is caused by the request (invalid, not found, canceled and others below 500), not by the service
+func ClientSide(err error) bool {
+	if err == nil {
+		return false
+	}
+	// Errors of database and context are classified as Database does
+	if _, ok := status.FromError(err); !ok {
+		err = Database(err)
+	}
+	return HTTPStatus(err) < http.StatusInternalServerError
+}
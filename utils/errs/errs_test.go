@@ -0,0 +1,66 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, http.StatusOK},
+		{status.Error(codes.OK, ""), http.StatusOK},
+		{status.Error(codes.InvalidArgument, ""), http.StatusBadRequest},
+		{status.Error(codes.OutOfRange, ""), http.StatusBadRequest},
+		{status.Error(codes.FailedPrecondition, ""), http.StatusBadRequest},
+		{status.Error(codes.Unauthenticated, ""), http.StatusUnauthorized},
+		{status.Error(codes.PermissionDenied, ""), http.StatusForbidden},
+		{status.Error(codes.NotFound, ""), http.StatusNotFound},
+		{status.Error(codes.AlreadyExists, ""), http.StatusConflict},
+		{status.Error(codes.Aborted, ""), http.StatusConflict},
+		{status.Error(codes.ResourceExhausted, ""), http.StatusTooManyRequests},
+		{status.Error(codes.Canceled, ""), StatusClientClosedRequest},
+		{status.Error(codes.Unavailable, ""), http.StatusServiceUnavailable},
+		{status.Error(codes.DeadlineExceeded, ""), http.StatusGatewayTimeout},
+		{status.Error(codes.Unimplemented, ""), http.StatusNotImplemented},
+		{status.Error(codes.Internal, ""), http.StatusInternalServerError},
+		{status.Error(codes.Unknown, ""), http.StatusInternalServerError},
+		{status.Error(codes.DataLoss, ""), http.StatusInternalServerError},
+		{New(NotFound, ReasonUserNotFound, "User not found"), http.StatusNotFound},
+		{Invalid("login", "Login is not supplied"), http.StatusBadRequest},
+		{errors.New("plain"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.err); got != tt.code {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", tt.err, got, tt.code)
+		}
+	}
+}
+
+func TestClientSide(t *testing.T) {
+	tests := []struct {
+		err    error
+		client bool
+	}{
+		{nil, false},
+		{New(NotFound, ReasonUserNotFound, "User not found"), true},
+		{Invalid("login", "Login is not supplied"), true},
+		{context.Canceled, true},
+		{context.DeadlineExceeded, false},
+		{errors.New("plain"), false},
+		{status.Error(codes.Unavailable, ""), false},
+		{status.Error(codes.NotFound, ""), true},
+	}
+	for _, tt := range tests {
+		if got := ClientSide(tt.err); got != tt.client {
+			t.Errorf("ClientSide(%v) = %v, want %v", tt.err, got, tt.client)
+		}
+	}
+}
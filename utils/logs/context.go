@@ -0,0 +1,44 @@
+// Request scoped logging: request id travels in context, every log line of the request carries it
+
+package logs
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Metadata key, used to pass request id in grpc calls
+const RequestIDKey = "api-req-id"
+
+type ctxKey int
+
+const requestIDCtxKey ctxKey = iota
+
+// Returns context, which carries request id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey, requestID)
+}
+
+// Returns request id from context, empty if there is none
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDCtxKey).(string)
+	return requestID
+}
+
+// Returns logger, which adds request id and trace id (if any) of the context to every line
+func With(ctx context.Context, logger *zap.SugaredLogger) *zap.SugaredLogger {
+	if requestID := RequestID(ctx); requestID != "" {
+		logger = logger.With("request_id", requestID)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		logger = logger.With("trace_id", sc.TraceID().String())
+	}
+	return logger
+}
+
+// Returns default logger for the request context
+func Ctx(ctx context.Context) *zap.SugaredLogger {
+	return With(ctx, Logger)
+}